@@ -0,0 +1,13 @@
+data "yba_kms_configs" "configs" {
+  // To fetch any KMS config
+}
+
+data "yba_kms_configs" "aws_configs" {
+  // To fetch only configs of one KMS provider
+  kms_provider = "AWS"
+}
+
+data "yba_kms_configs" "config" {
+  // To fetch id of a particular KMS config
+  config_name = "<kms-config-name>"
+}
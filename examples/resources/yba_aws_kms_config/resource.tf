@@ -0,0 +1,17 @@
+// AWS KMS configuration with access keys. YugabyteDB Anywhere creates a new
+// customer master key.
+resource "yba_aws_kms_config" "aws" {
+  name              = "aws-kms"
+  region            = "us-west-2"
+  access_key_id     = var.aws_access_key_id
+  secret_access_key = var.aws_secret_access_key
+}
+
+// AWS KMS configuration using the IAM role of the YugabyteDB Anywhere host and
+// an existing key.
+resource "yba_aws_kms_config" "aws_iam" {
+  name                     = "aws-kms-iam"
+  region                   = "us-west-2"
+  use_iam_instance_profile = true
+  cmk_id                   = "arn:aws:kms:us-west-2:123456789012:key/<key-id>"
+}
@@ -0,0 +1,8 @@
+resource "yba_azure_kms_config" "azure" {
+  name          = "azure-kms"
+  client_id     = var.azure_client_id
+  client_secret = var.azure_client_secret
+  tenant_id     = var.azure_tenant_id
+  vault_url     = "https://my-vault.vault.azure.net/"
+  key_name      = "yugabyte-key"
+}
@@ -0,0 +1,13 @@
+resource "yba_dr_config" "orders" {
+  name                     = "orders-dr"
+  primary_universe_uuid    = yba_universe.primary.id
+  dr_replica_universe_uuid = yba_universe.standby.id
+  dbs                      = ["orders", "inventory"]
+
+  bootstrap_params {
+    storage_config_uuid = yba_s3_storage_config.backups.id
+  }
+
+  // Change to a new value to switch the roles of the two universes over.
+  switchover_trigger = "2026-10-01"
+}
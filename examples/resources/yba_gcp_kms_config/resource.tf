@@ -0,0 +1,7 @@
+resource "yba_gcp_kms_config" "gcp" {
+  name          = "gcp-kms"
+  credentials   = file("~/.gcp/kms-service-account.json")
+  location_id   = "us-central1"
+  key_ring_id   = "yugabyte-key-ring"
+  crypto_key_id = "yugabyte-key"
+}
@@ -0,0 +1,15 @@
+// HashiCorp Vault KMS configuration authenticating with a token.
+resource "yba_hashicorp_vault_kms_config" "vault" {
+  name          = "vault-kms"
+  vault_address = "https://vault.example.com:8200"
+  token         = var.vault_token
+}
+
+// HashiCorp Vault KMS configuration authenticating with AppRole.
+resource "yba_hashicorp_vault_kms_config" "vault_approle" {
+  name          = "vault-kms-approle"
+  vault_address = "https://vault.example.com:8200"
+  role_id       = var.vault_role_id
+  secret_id     = var.vault_secret_id
+  mount_path    = "yugabyte-transit/"
+}
@@ -0,0 +1,45 @@
+# Kubernetes provider for a GKE cluster, with a kubeconfig per zone
+resource "yba_kubernetes_provider" "gke" {
+  name                     = "gke-provider"
+  kubernetes_provider_type = "gke"
+  storage_class            = "standard-rwo"
+  image_registry           = "quay.io/yugabyte/yugabyte"
+  pull_secret_name         = "yugabyte-k8s-pull-secret"
+  pull_secret_content      = file("~/.kube/yugabyte-pull-secret.yaml")
+
+  regions {
+    code = "us-west1"
+
+    zones {
+      code               = "us-west1-a"
+      namespace          = "yb-west1-a"
+      kubeconfig_content = file("~/.kube/us-west1-a.conf")
+    }
+    zones {
+      code               = "us-west1-b"
+      namespace          = "yb-west1-b"
+      storage_class      = "premium-rwo"
+      kubeconfig_content = file("~/.kube/us-west1-b.conf")
+      overrides          = <<-EOT
+        nodeSelector:
+          cloud.google.com/gke-nodepool: yugabyte
+      EOT
+    }
+  }
+}
+
+# Single-cluster provider sharing one kubeconfig and namespace
+resource "yba_kubernetes_provider" "custom" {
+  name                     = "k8s-provider"
+  kubernetes_provider_type = "custom"
+  kubeconfig_content       = file("~/.kube/config")
+
+  regions {
+    code      = "us-east1"
+    namespace = "yugabyte"
+
+    zones {
+      code = "us-east1-b"
+    }
+  }
+}
@@ -0,0 +1,7 @@
+resource "yba_pitr_config" "orders" {
+  universe_uuid                = yba_universe.example.id
+  namespace                    = "orders"
+  table_type                   = "PGSQL_TABLE_TYPE"
+  retention_period_in_seconds  = 7 * 24 * 3600
+  snapshot_interval_in_seconds = 3600
+}
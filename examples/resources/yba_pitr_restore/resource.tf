@@ -0,0 +1,5 @@
+resource "yba_pitr_restore" "orders" {
+  universe_uuid    = yba_universe.example.id
+  pitr_config_uuid = yba_pitr_config.orders.id
+  restore_time     = "2026-10-01T12:00:00Z"
+}
@@ -128,3 +128,43 @@ resource "yba_universe" "with_certificates" {
   }
   communication_ports {}
 }
+
+# Universe on a Kubernetes provider. instance_type names a YugabyteDB Anywhere
+# Kubernetes instance type; the kubernetes block sets the pod resources and
+# Helm overrides. Changing the overrides restarts the pods one at a time.
+resource "yba_universe" "kubernetes" {
+  clusters {
+    cluster_type = "PRIMARY"
+    user_intent {
+      universe_name      = "<universe-name>"
+      provider           = yba_kubernetes_provider.k8s.id
+      region_list        = yba_kubernetes_provider.k8s.regions[*].uuid
+      num_nodes          = 3
+      replication_factor = 3
+      instance_type      = "small"
+      device_info {
+        num_volumes = 1
+        volume_size = 100
+      }
+      kubernetes {
+        universe_overrides = <<-EOT
+          tserver:
+            podLabels:
+              team: payments
+        EOT
+        tserver_resources {
+          cpu        = 4
+          memory_gib = 8
+        }
+        master_resources {
+          cpu        = 2
+          memory_gib = 4
+        }
+        expose_service = "UNEXPOSED"
+      }
+      enable_ysql         = true
+      yb_software_version = data.yba_release_version.release_version.id
+    }
+  }
+  communication_ports {}
+}
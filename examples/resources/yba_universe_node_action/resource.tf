@@ -0,0 +1,7 @@
+# Reboot a misbehaving node. Bump trigger to reboot it again later.
+resource "yba_universe_node_action" "reboot_n2" {
+  universe_uuid = yba_universe.example.id
+  node_name     = "yb-dev-orders-n2"
+  action        = "REBOOT"
+  trigger       = "2026-10-16"
+}
@@ -0,0 +1,33 @@
+resource "yba_universe" "example" {
+  external_read_replica = true
+  clusters {
+    cluster_type = "PRIMARY"
+    user_intent {
+      # ... primary cluster settings
+    }
+  }
+}
+
+resource "yba_universe_read_replica" "analytics" {
+  universe_uuid = yba_universe.example.id
+  user_intent {
+    provider           = yba_aws_provider.aws.id
+    region_list        = yba_aws_provider.aws.regions[*].uuid
+    num_nodes          = 3
+    replication_factor = 3
+    instance_type      = "<instance-type>"
+    access_key_code    = data.yba_provider_key.cloud_key.id
+    device_info {
+      num_volumes  = 1
+      volume_size  = 375
+      storage_type = "<storage-type>"
+    }
+    specific_gflags {
+      per_process {
+        tserver_gflags = {
+          ysql_enable_packed_row = "true"
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1,21 @@
+// Transactional replication of two YSQL databases, copying their existing
+// data to the target universe first.
+resource "yba_xcluster_config" "orders" {
+  name                 = "orders-replication"
+  source_universe_uuid = yba_universe.primary.id
+  target_universe_uuid = yba_universe.standby.id
+  ysql_databases       = ["orders", "inventory"]
+  transactional        = true
+
+  bootstrap {
+    storage_config_uuid = yba_s3_storage_config.backups.id
+  }
+}
+
+// Replication of selected YCQL tables.
+resource "yba_xcluster_config" "events" {
+  name                 = "events-replication"
+  source_universe_uuid = yba_universe.primary.id
+  target_universe_uuid = yba_universe.standby.id
+  ycql_tables          = ["events.clicks", "events.views"]
+}
@@ -13,6 +13,7 @@ require (
 	github.com/yugabyte/platform-go-client v0.0.0-20260612051126-0b0414ece83e
 	github.com/yugabyte/platform-go-client/v2 v2.0.0-20260612051126-0b0414ece83e
 	golang.org/x/crypto v0.55.0
+	golang.org/x/net v0.57.0
 )
 
 require (
@@ -83,7 +84,6 @@ require (
 	go.opentelemetry.io/otel/sdk/metric v1.44.0 // indirect
 	golang.org/x/exp v0.0.0-20260603202125-055de637280b // indirect
 	golang.org/x/mod v0.38.0 // indirect
-	golang.org/x/net v0.57.0 // indirect
 	golang.org/x/sync v0.22.0 // indirect
 	golang.org/x/sys v0.47.0 // indirect
 	golang.org/x/text v0.41.0 // indirect
@@ -103,6 +103,23 @@ func TestAPIKey() string {
 	return getEnvMulti("YBA_API_KEY", "YB_API_KEY")
 }
 
+// TestClientConfig returns the connection settings for a test YBA. The
+// fixture YBAs serve self-signed certificates, so verification is skipped
+// unless YBA_CA_CERT_FILE points at their CA.
+func TestClientConfig(host, apiKey string) api.ClientConfig {
+	caFile := os.Getenv("YBA_CA_CERT_FILE")
+	return api.ClientConfig{
+		Host:        host,
+		APIKey:      apiKey,
+		EnableHTTPS: true,
+		TLS: api.TLSConfig{
+			CACertFile:         caFile,
+			InsecureSkipVerify: caFile == "",
+		},
+		Retry: api.DefaultRetryConfig,
+	}
+}
+
 // CloudYBAHost returns TF_VAR_<CLOUD>_YBA_HOST (cloud is the upper-case code,
 // e.g. "AWS"). Each cloud has its own YBA. Provider tests must target the YBA
 // running on that cloud, because use_iam_instance_profile authenticates with
@@ -120,7 +137,7 @@ func APIClientForCloud(cloud string) (*api.APIClient, error) {
 	if c, ok := cloudClients[cloud]; ok {
 		return c, nil
 	}
-	c, err := api.NewAPIClient(true, CloudYBAHost(cloud), CloudYBAAPIKey(cloud))
+	c, err := api.NewAPIClient(TestClientConfig(CloudYBAHost(cloud), CloudYBAAPIKey(cloud)))
 	if err != nil {
 		return nil, fmt.Errorf("%s fixture YBA (TF_VAR_%s_YBA_HOST=%s) is unreachable: %w",
 			cloud, cloud, CloudYBAHost(cloud), err)
@@ -221,7 +238,7 @@ func init() {
 	if TestHost() == "" {
 		return
 	}
-	c, err := api.NewAPIClient(true, TestHost(), TestAPIKey())
+	c, err := api.NewAPIClient(TestClientConfig(TestHost(), TestAPIKey()))
 	if err != nil {
 		// Never panic here: that kills every test binary importing this
 		// package, including per-cloud provider tests that talk only to their
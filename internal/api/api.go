@@ -19,11 +19,9 @@ package api
 
 import (
 	"context"
-	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
-	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -42,53 +40,152 @@ type APIClient struct {
 	APIKey           string
 	CustomerID       string
 	UserID           string // UUID of the logged-in user (API token holder)
+
+	// AbortTasksOnCancel is the abort_tasks_on_cancel provider setting, passed
+	// to utils.DispatchAndWait and utils.ResumePendingTask.
+	AbortTasksOnCancel bool
+
+	// config, transport and session are kept so WithAPIKey can build a
+	// client for a different token over the same connection settings.
+	config    ClientConfig
+	transport http.RoundTripper
+	session   *sessionAuth
+}
+
+// ClientConfig holds the provider-level connection settings for YBA.
+type ClientConfig struct {
+	Host        string
+	APIKey      string
+	EnableHTTPS bool
+	TLS         TLSConfig
+	Proxy       ProxyConfig
+	Retry       RetryConfig
+	RateLimit   RateLimitConfig
+	// CacheTTL enables the response cache for repeated list and universe
+	// reads. Zero disables it.
+	CacheTTL time.Duration
+	// AbortTasksOnCancel aborts the YBA task being waited on when Terraform
+	// is interrupted; see utils.DispatchAndWait.
+	AbortTasksOnCancel bool
+	// DefaultTags are the instance tags added to the nodes of every
+	// yba_universe cluster on a cloud provider.
+	DefaultTags map[string]string
+	// Headers are added to every request from all three clients.
+	Headers map[string]string
+
+	// Email and Password log in through api/v1/login for a session token,
+	// used in place of APIKey and renewed when it expires.
+	Email    string
+	Password string
+	// BearerToken is an OIDC JWT sent as "Authorization: Bearer", for YBA
+	// deployments fronted by SSO.
+	BearerToken string
+}
+
+// Authenticated reports whether cfg carries any credentials. Without them
+// the provider only bootstraps YBA (installer, customer registration).
+func (cfg ClientConfig) Authenticated() bool {
+	return cfg.APIKey != "" || cfg.Email != "" || cfg.BearerToken != ""
 }
 
 // NewAPIClient creates a wrapper for public and non-public APIs
-func NewAPIClient(enableHTTPS bool, host, apiKey string) (*APIClient, error) {
-	// Normalize host - strip scheme if provided (we add it based on enableHTTPS)
-	host = strings.TrimPrefix(host, "https://")
-	host = strings.TrimPrefix(host, "http://")
-	host = strings.TrimSuffix(host, "/")
+func NewAPIClient(cfg ClientConfig) (*APIClient, error) {
+	// Normalize host - strip scheme if provided (we add it based on EnableHTTPS)
+	cfg.Host = strings.TrimPrefix(cfg.Host, "https://")
+	cfg.Host = strings.TrimPrefix(cfg.Host, "http://")
+	cfg.Host = strings.TrimSuffix(cfg.Host, "/")
+
+	base, err := newTransport(cfg.TLS, cfg.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	tr := newCacheTransport(
+		newRetryTransport(newThrottleTransport(base, cfg.RateLimit), cfg.Retry), cfg.CacheTTL)
+	var session *sessionAuth
+	if cfg.APIKey == "" && (cfg.Email != "" || cfg.BearerToken != "") {
+		session = newSessionAuth(tr, cfg)
+		if cfg.Email != "" {
+			if _, err := session.refresh(context.Background(), ""); err != nil {
+				return nil, fmt.Errorf("Provider Creation: NewAPIClient, Operation: Login - %w",
+					err)
+			}
+		}
+	}
+	return newAPIClient(cfg, tr, session)
+}
+
+// DefaultTags returns the instance tags the provider adds to every universe
+// cluster on a cloud provider.
+func (c *APIClient) DefaultTags() map[string]string {
+	return c.config.DefaultTags
+}
+
+// WithAPIKey returns a client for apiKey that reuses c's connection settings
+// and transport.
+func (c *APIClient) WithAPIKey(apiKey string) (*APIClient, error) {
+	cfg := c.config
+	cfg.APIKey = apiKey
+	session := c.session
+	if apiKey != "" {
+		// An explicit token replaces the provider's login session.
+		cfg.Email, cfg.Password, cfg.BearerToken = "", "", ""
+		session = nil
+	}
+	if c.transport == nil {
+		return NewAPIClient(cfg)
+	}
+	return newAPIClient(cfg, c.transport, session)
+}
+
+// vanillaAttemptTimeout bounds each attempt of a VanillaClient request.
+const vanillaAttemptTimeout = 30 * time.Second
+
+func newAPIClient(
+	cfg ClientConfig, base http.RoundTripper, session *sessionAuth,
+) (*APIClient, error) {
+	host := cfg.Host
+	apiKey := cfg.APIKey
+
+	tr := base
+	if session != nil {
+		tr = session
+	}
+
+	scheme := "http"
+	if cfg.EnableHTTPS {
+		scheme = "https"
+	}
 
 	// create swagger go client (v1)
-	cfg := client.NewConfiguration()
-	cfg.Host = host
+	cfgV1 := client.NewConfiguration()
+	cfgV1.Host = host
+	cfgV1.Scheme = scheme
+	cfgV1.HTTPClient = &http.Client{Transport: tr}
 	// create v2 swagger go client (separate Go module, separate config)
 	cfgV2 := clientv2.NewConfiguration()
 	cfgV2.Host = host
-	if enableHTTPS {
-		// Bound connection setup and time-to-first-byte. Without these, a
-		// connection that dies without an RST (a black-holed load balancer or
-		// tunnel) hangs its request forever — GetSessionInfo below runs on
-		// context.Background(), so provider configure would never return. No
-		// overall client Timeout: response bodies of any size stay unbounded.
-		tr := &http.Transport{
-			TLSClientConfig:       &tls.Config{InsecureSkipVerify: true},
-			DialContext:           (&net.Dialer{Timeout: 30 * time.Second}).DialContext,
-			TLSHandshakeTimeout:   30 * time.Second,
-			ResponseHeaderTimeout: 2 * time.Minute,
-		}
-		cfg.Scheme = "https"
-		cfg.HTTPClient = &http.Client{Transport: tr}
-		cfgV2.Scheme = "https"
-		cfgV2.HTTPClient = &http.Client{Transport: tr}
-	} else {
-		cfg.Scheme = "http"
-		cfgV2.Scheme = "http"
+	cfgV2.Scheme = scheme
+	cfgV2.HTTPClient = &http.Client{Transport: tr}
+	for k, v := range cfg.Headers {
+		cfgV1.AddDefaultHeader(k, v)
+		cfgV2.AddDefaultHeader(k, v)
 	}
 	if apiKey != "" {
-		cfg.DefaultHeader = map[string]string{"X-AUTH-YW-API-TOKEN": apiKey}
-		cfgV2.DefaultHeader = map[string]string{"X-AUTH-YW-API-TOKEN": apiKey}
+		cfgV1.AddDefaultHeader("X-AUTH-YW-API-TOKEN", apiKey)
+		cfgV2.AddDefaultHeader("X-AUTH-YW-API-TOKEN", apiKey)
 	}
-	ywc := client.NewAPIClient(cfg)
+	ywc := client.NewAPIClient(cfgV1)
 	ywcV2 := clientv2.NewAPIClient(cfgV2)
 
 	// create vanilla client for non-public APIs
 	vc := &VanillaClient{
-		Client:      &http.Client{Timeout: 30 * time.Second},
+		Client: &http.Client{
+			Transport: tr,
+			Timeout:   cfg.Retry.clientTimeout(vanillaAttemptTimeout),
+		},
 		Host:        host,
-		EnableHTTPS: enableHTTPS,
+		EnableHTTPS: cfg.EnableHTTPS,
+		Headers:     cfg.Headers,
 	}
 
 	// create wrapper client
@@ -97,10 +194,15 @@ func NewAPIClient(enableHTTPS bool, host, apiKey string) (*APIClient, error) {
 		YugawareClient:   ywc,
 		YugawareClientV2: ywcV2,
 		APIKey:           apiKey,
+		config:           cfg,
+		transport:        base,
+		session:          session,
+
+		AbortTasksOnCancel: cfg.AbortTasksOnCancel,
 	}
 
-	// authenticate if api token is provided
-	if apiKey != "" {
+	// authenticate if credentials are provided
+	if cfg.Authenticated() {
 		r, response, err := c.YugawareClient.SessionManagementAPI.GetSessionInfo(
 			context.Background()).Execute()
 		if err != nil {
@@ -125,6 +227,17 @@ type VanillaClient struct {
 	Client      *http.Client
 	Host        string
 	EnableHTTPS bool
+	// Headers are the provider's custom_headers, set on every request.
+	Headers map[string]string
+}
+
+// requestURL returns the absolute URL for a path relative to the YBA host.
+func (vc VanillaClient) requestURL(path string) string {
+	scheme := "http"
+	if vc.EnableHTTPS {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s/%s", scheme, vc.Host, path)
 }
 
 func (vc VanillaClient) makeRequest(
@@ -135,38 +248,11 @@ func (vc VanillaClient) makeRequest(
 	apiKey string,
 ) (
 	*http.Response, error) {
-	var req *http.Request
-	var err error
-	if vc.EnableHTTPS {
-		tr, ok := http.DefaultTransport.(*http.Transport)
-		if !ok {
-			return nil, errors.New("http.DefaultTransport is not *http.Transport")
-		}
-		tr.TLSClientConfig = &tls.Config{
-			InsecureSkipVerify: true, //nolint:gosec // TLS verification intentionally disabled for YBA self-signed certs
-		}
-		req, err = http.NewRequestWithContext(
-			ctx,
-			method,
-			fmt.Sprintf("https://%s/%s", vc.Host, url),
-			body,
-		)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		req, err = http.NewRequestWithContext(
-			ctx,
-			method,
-			fmt.Sprintf("http://%s/%s", vc.Host, url),
-			body,
-		)
-		if err != nil {
-			return nil, err
-		}
+	req, err := http.NewRequestWithContext(ctx, method, vc.requestURL(url), body)
+	if err != nil {
+		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-AUTH-YW-API-TOKEN", apiKey)
+	vc.setHeaders(req, apiKey)
 
 	r, err := vc.Client.Do(req) //nolint:bodyclose // caller is responsible for closing r.Body
 	if err != nil {
@@ -174,3 +260,15 @@ func (vc VanillaClient) makeRequest(
 	}
 	return r, err
 }
+
+// setHeaders applies the custom headers, then the JSON content type and the
+// API token, so a custom header can never replace the token.
+func (vc VanillaClient) setHeaders(req *http.Request, apiKey string) {
+	for k, v := range vc.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		req.Header.Set("X-AUTH-YW-API-TOKEN", apiKey)
+	}
+}
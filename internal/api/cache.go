@@ -0,0 +1,208 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+// DefaultCacheTTL is how long a cached YBA response is served. It only has
+// to cover the burst of identical reads one plan or apply makes.
+const DefaultCacheTTL = 30 * time.Second
+
+// cacheablePaths are the read-heavy GETs every universe, backup and provider
+// resource repeats during a refresh: GetListOfProviders,
+// GetListOfCustomerConfig and GetUniverse.
+var cacheablePaths = []*regexp.Regexp{
+	regexp.MustCompile(`^/api/v1/customers/[^/]+/providers$`),
+	regexp.MustCompile(`^/api/v1/customers/[^/]+/configs$`),
+	regexp.MustCompile(`^/api/v1/customers/[^/]+/universes/[^/]+$`),
+}
+
+// taskStatusPath matches CustomerTasksAPI.TaskStatus, polled by
+// utils.WaitForTask.
+var taskStatusPath = regexp.MustCompile(`^/api/v1/customers/[^/]+/tasks/[^/]+$`)
+
+// authHeaders identify the caller; responses are never shared between
+// credentials.
+var authHeaders = []string{"X-AUTH-YW-API-TOKEN", sessionTokenHeader, "Authorization"}
+
+type cachedResponse struct {
+	status  string
+	code    int
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+// cacheTransport serves repeated reads of cacheablePaths from memory.
+//
+// Any request that is not a GET or HEAD empties the cache, before it is sent
+// and again once it returns, so a resource always reads its own writes. A
+// task reaching a final state empties it too: the task changed the universe
+// behind YBA's API, and reads taken while it ran are stale.
+type cacheTransport struct {
+	base http.RoundTripper
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*cachedResponse
+	// generation counts flushes, so a read that was in flight during a flush
+	// is not stored afterwards.
+	generation uint64
+}
+
+func newCacheTransport(base http.RoundTripper, ttl time.Duration) http.RoundTripper {
+	if ttl <= 0 {
+		return base
+	}
+	return &cacheTransport{base: base, ttl: ttl, entries: make(map[string]*cachedResponse)}
+}
+
+// Unwrap returns the wrapped transport.
+func (t *cacheTransport) Unwrap() http.RoundTripper {
+	return t.base
+}
+
+func (t *cacheTransport) flush() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	clear(t.entries)
+	t.generation++
+}
+
+func cacheKey(req *http.Request) string {
+	var b bytes.Buffer
+	b.WriteString(req.URL.String())
+	for _, h := range authHeaders {
+		b.WriteByte(0)
+		b.WriteString(req.Header.Get(h))
+	}
+	return b.String()
+}
+
+func isCacheable(req *http.Request) bool {
+	if req.Method != http.MethodGet || (req.Body != nil && req.Body != http.NoBody) {
+		return false
+	}
+	for _, p := range cacheablePaths {
+		if p.MatchString(req.URL.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		t.flush()
+		defer t.flush()
+		return t.base.RoundTrip(req)
+	}
+	if req.Method == http.MethodGet && taskStatusPath.MatchString(req.URL.Path) {
+		return t.taskStatus(req)
+	}
+	if !isCacheable(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+	t.mu.Lock()
+	e, ok := t.entries[key]
+	if ok && time.Now().After(e.expires) {
+		delete(t.entries, key)
+		ok = false
+	}
+	generation := t.generation
+	t.mu.Unlock()
+	if ok {
+		tflog.Trace(req.Context(), "Serving cached YugabyteDB Anywhere response",
+			map[string]interface{}{"path": req.URL.Path})
+		return e.response(req), nil
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	e = &cachedResponse{
+		status:  resp.Status,
+		code:    resp.StatusCode,
+		header:  resp.Header.Clone(),
+		body:    body,
+		expires: time.Now().Add(t.ttl),
+	}
+	t.mu.Lock()
+	if t.generation == generation {
+		t.entries[key] = e
+	}
+	t.mu.Unlock()
+	return e.response(req), nil
+}
+
+// taskStatus passes a task poll through and empties the cache once the task
+// is no longer running.
+func (t *cacheTransport) taskStatus(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	var task struct {
+		Status string `json:"status"`
+	}
+	if json.Unmarshal(body, &task) != nil ||
+		!slices.Contains(utils.PendingTaskStates, task.Status) {
+		t.flush()
+	}
+	return resp, nil
+}
+
+func (e *cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        e.status,
+		StatusCode:    e.code,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
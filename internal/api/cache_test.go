@@ -0,0 +1,106 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// countingYBA answers every request with a body naming how many times the
+// path has been fetched, so a test can tell a cached read from a fresh one.
+type countingYBA struct {
+	hits       map[string]int
+	taskStatus string
+}
+
+func (f *countingYBA) RoundTrip(r *http.Request) (*http.Response, error) {
+	f.hits[r.URL.Path]++
+	body := fmt.Sprintf(`{"fetch":%d}`, f.hits[r.URL.Path])
+	if taskStatusPath.MatchString(r.URL.Path) {
+		body = fmt.Sprintf(`{"status":%q}`, f.taskStatus)
+	}
+	resp := statusResponse(http.StatusOK)
+	resp.Body = io.NopCloser(bytes.NewBufferString(body))
+	return resp, nil
+}
+
+func cachedGet(t *testing.T, rt http.RoundTripper, path, token string) string {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, "http://yba"+path, nil)
+	req.Header.Set("X-AUTH-YW-API-TOKEN", token)
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("GET %s: %v", path, err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(resp.Body)
+	return string(b)
+}
+
+func TestCacheTransport(t *testing.T) {
+	const uni = "/api/v1/customers/c/universes/u"
+	f := &countingYBA{hits: map[string]int{}}
+	rt := newCacheTransport(f, time.Minute)
+
+	if got := cachedGet(t, rt, uni, "tok"); got != `{"fetch":1}` {
+		t.Fatalf("first read = %s", got)
+	}
+	if got := cachedGet(t, rt, uni, "tok"); got != `{"fetch":1}` {
+		t.Errorf("repeated read = %s, want the cached response", got)
+	}
+	if got := cachedGet(t, rt, uni, "other"); got != `{"fetch":2}` {
+		t.Errorf("read with another token = %s, want a fresh response", got)
+	}
+	if got := cachedGet(t, rt, "/api/v1/customers/c/universes/u/status", "tok"); got !=
+		`{"fetch":1}` || cachedGet(t, rt, "/api/v1/customers/c/universes/u/status", "tok") !=
+		`{"fetch":2}` {
+		t.Error("paths outside the cacheable list must not be cached")
+	}
+
+	req, _ := http.NewRequest(http.MethodPut, "http://yba"+uni, bytes.NewBufferString("{}"))
+	resp, _ := rt.RoundTrip(req)
+	_ = resp.Body.Close()
+	if got := cachedGet(t, rt, uni, "tok"); got != `{"fetch":4}` {
+		t.Errorf("read after a write = %s, want a fresh response", got)
+	}
+
+	f.taskStatus = "Running"
+	cachedGet(t, rt, "/api/v1/customers/c/tasks/t", "tok")
+	if got := cachedGet(t, rt, uni, "tok"); got != `{"fetch":4}` {
+		t.Errorf("read while a task runs = %s, want the cached response", got)
+	}
+	f.taskStatus = "Success"
+	cachedGet(t, rt, "/api/v1/customers/c/tasks/t", "tok")
+	if got := cachedGet(t, rt, uni, "tok"); got != `{"fetch":5}` {
+		t.Errorf("read after a task finished = %s, want a fresh response", got)
+	}
+}
+
+func TestCacheTransportExpires(t *testing.T) {
+	const providers = "/api/v1/customers/c/providers"
+	f := &countingYBA{hits: map[string]int{}}
+	rt := newCacheTransport(f, 10*time.Millisecond)
+	cachedGet(t, rt, providers, "tok")
+	time.Sleep(20 * time.Millisecond)
+	if got := cachedGet(t, rt, providers, "tok"); got != `{"fetch":2}` {
+		t.Errorf("read after the TTL = %s, want a fresh response", got)
+	}
+}
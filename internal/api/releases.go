@@ -18,7 +18,6 @@ package api
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -68,27 +67,13 @@ func (vc *VanillaClient) ReleaseImport(ctx context.Context, cUUID string, versio
 
 	reqBuf := bytes.NewBuffer(reqBytes)
 
-	var req *http.Request
-	if vc.EnableHTTPS {
-		tr, ok := http.DefaultTransport.(*http.Transport)
-		if !ok {
-			return false, errors.New("http.DefaultTransport is not *http.Transport")
-		}
-		tr.TLSClientConfig = &tls.Config{
-			InsecureSkipVerify: true, //nolint:gosec // TLS verification intentionally disabled for YBA self-signed certs
-		}
-		req, err = http.NewRequestWithContext(ctx, http.MethodPost,
-			fmt.Sprintf("https://%s/api/v1/customers/%s/releases", vc.Host, cUUID), reqBuf)
-	} else {
-		req, err = http.NewRequestWithContext(ctx, http.MethodPost,
-			fmt.Sprintf("http://%s/api/v1/customers/%s/releases", vc.Host, cUUID), reqBuf)
-	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		vc.requestURL(fmt.Sprintf("api/v1/customers/%s/releases", cUUID)), reqBuf)
 	if err != nil {
 		return false, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-AUTH-YW-API-TOKEN", token)
+	vc.setHeaders(req, token)
 
 	r, err := vc.Client.Do(req)
 	if err != nil {
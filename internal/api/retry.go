@@ -0,0 +1,205 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RetryConfig controls client-side retries of transient YBA failures. These
+// are distinct from the 409 universe-task-conflict retries in
+// utils.DispatchAndWait, which wait on YBA's task lock rather than on YBA
+// itself.
+type RetryConfig struct {
+	// MaxRetries is the number of retries after the first attempt. Zero
+	// disables retrying.
+	MaxRetries int
+	// WaitMin and WaitMax bound the exponential backoff between attempts.
+	WaitMin time.Duration
+	WaitMax time.Duration
+}
+
+// DefaultRetryConfig matches the provider block defaults.
+var DefaultRetryConfig = RetryConfig{
+	MaxRetries: 4,
+	WaitMin:    1 * time.Second,
+	WaitMax:    30 * time.Second,
+}
+
+// retryableStatus are the gateway responses seen while YBA restarts behind a
+// load balancer or ingress.
+var retryableStatus = map[int]bool{
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// retryTransport retries transient failures.
+//
+// Idempotent requests (GET, HEAD, OPTIONS) are retried on any transport error
+// and on 502/503/504. Mutating requests are retried only when the connection
+// could not be established, since then YBA cannot have acted on them; a
+// mutating request that might have been received is never repeated, as that
+// could queue a universe task twice.
+type retryTransport struct {
+	base http.RoundTripper
+	cfg  RetryConfig
+}
+
+func newRetryTransport(base http.RoundTripper, cfg RetryConfig) http.RoundTripper {
+	if cfg.MaxRetries <= 0 {
+		return base
+	}
+	return &retryTransport{base: base, cfg: cfg.normalized()}
+}
+
+// normalized fills in the backoff bounds a retrying transport needs.
+func (cfg RetryConfig) normalized() RetryConfig {
+	if cfg.WaitMin <= 0 {
+		cfg.WaitMin = DefaultRetryConfig.WaitMin
+	}
+	if cfg.WaitMax < cfg.WaitMin {
+		cfg.WaitMax = cfg.WaitMin
+	}
+	return cfg
+}
+
+// clientTimeout returns an http.Client Timeout that gives each attempt of a
+// request up to attempt, plus the longest backoff before every retry. A
+// client Timeout covers the whole RoundTrip, so a plain per-request value
+// would cut the retries off.
+func (cfg RetryConfig) clientTimeout(attempt time.Duration) time.Duration {
+	if cfg.MaxRetries <= 0 {
+		return attempt
+	}
+	cfg = cfg.normalized()
+	return time.Duration(cfg.MaxRetries+1)*attempt + time.Duration(cfg.MaxRetries)*cfg.WaitMax
+}
+
+// Unwrap returns the wrapped transport.
+func (t *retryTransport) Unwrap() http.RoundTripper {
+	return t.base
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.Body != nil {
+			if req.GetBody == nil {
+				// Unreachable for retried requests: retryable() refuses a
+				// body that cannot be replayed.
+				return nil, errors.New("request body cannot be replayed")
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if attempt >= t.cfg.MaxRetries || !t.retryable(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		fields := map[string]interface{}{
+			"method":  req.Method,
+			"path":    req.URL.Path,
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.StatusCode
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		tflog.Warn(ctx, "Transient YugabyteDB Anywhere API failure, retrying", fields)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (t *retryTransport) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if isIdempotent(req.Method) {
+		return err != nil || retryableStatus[resp.StatusCode]
+	}
+	return err != nil && neverReachedServer(err)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// neverReachedServer reports whether err happened while dialing, before any
+// byte of the request could be sent.
+func neverReachedServer(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
+
+// backoff returns the wait before the retry following attempt: Retry-After
+// when YBA or the gateway sends one, else exponential with full jitter
+// between WaitMin and WaitMax.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && s > 0 {
+			wait := time.Duration(s) * time.Second
+			if wait > t.cfg.WaitMax {
+				wait = t.cfg.WaitMax
+			}
+			return wait
+		}
+	}
+	upper := t.cfg.WaitMin << attempt
+	if upper <= 0 || upper > t.cfg.WaitMax {
+		upper = t.cfg.WaitMax
+	}
+	return t.cfg.WaitMin + rand.N(upper-t.cfg.WaitMin+1) //nolint:gosec // jitter, not crypto
+}
@@ -0,0 +1,166 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func statusResponse(code int) *http.Response {
+	return &http.Response{
+		StatusCode: code,
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewReader(nil)),
+	}
+}
+
+var fastRetries = RetryConfig{MaxRetries: 3, WaitMin: time.Millisecond, WaitMax: time.Millisecond}
+
+var errDialRefused = &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+
+var errReset = &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+
+// TestRetryTransport: GETs ride out a YBA restart; mutations are repeated
+// only when they provably never reached YBA, so a universe task is never
+// queued twice.
+func TestRetryTransport(t *testing.T) {
+	cases := []struct {
+		name         string
+		method       string
+		results      []error // nil entries return 503
+		status       int
+		wantAttempts int
+	}{
+		{"GET 503 then ok", http.MethodGet, []error{nil, nil}, http.StatusOK, 3},
+		{"GET connection reset", http.MethodGet, []error{errReset}, http.StatusOK, 2},
+		{"GET gives up", http.MethodGet, []error{nil, nil, nil, nil}, 503, 4},
+		{"POST 503 not retried", http.MethodPost, []error{nil}, 503, 1},
+		{"POST reset not retried", http.MethodPost, []error{errReset}, 0, 1},
+		{"POST dial refused", http.MethodPost, []error{errDialRefused}, http.StatusOK, 2},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			attempts := 0
+			var bodies []string
+			rt := newRetryTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				attempts++
+				if r.Body != nil {
+					b, _ := io.ReadAll(r.Body)
+					bodies = append(bodies, string(b))
+				}
+				if attempts <= len(tc.results) {
+					if err := tc.results[attempts-1]; err != nil {
+						return nil, err
+					}
+					return statusResponse(http.StatusServiceUnavailable), nil
+				}
+				return statusResponse(http.StatusOK), nil
+			}), fastRetries)
+
+			var body io.Reader
+			if tc.method == http.MethodPost {
+				body = bytes.NewBufferString("payload")
+			}
+			req, _ := http.NewRequestWithContext(
+				context.Background(), tc.method, "http://yba/api/v1/x", body)
+			resp, err := rt.RoundTrip(req)
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+			if attempts != tc.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tc.wantAttempts)
+			}
+			switch {
+			case tc.status == 0 && err == nil:
+				t.Error("expected the transport error to be returned")
+			case tc.status != 0 && (err != nil || resp.StatusCode != tc.status):
+				t.Errorf("got (%v, %v), want status %d", resp, err, tc.status)
+			}
+			for i, b := range bodies {
+				if b != "payload" {
+					t.Errorf("attempt %d body = %q, want payload", i+1, b)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransportStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	rt := newRetryTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		cancel()
+		return statusResponse(http.StatusServiceUnavailable), nil
+	}), RetryConfig{MaxRetries: 3, WaitMin: time.Hour, WaitMax: time.Hour})
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://yba/api/v1/x", nil)
+	resp, err := rt.RoundTrip(req)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if resp == nil && !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want the 503 or context.Canceled", err)
+	}
+}
+
+func TestRetryTransportDisabled(t *testing.T) {
+	base := roundTripFunc(func(*http.Request) (*http.Response, error) { return nil, nil })
+	if _, ok := newRetryTransport(base, RetryConfig{}).(*retryTransport); ok {
+		t.Error("max_retries = 0 must not wrap the transport")
+	}
+}
+
+func TestRetryBackoffHonorsRetryAfter(t *testing.T) {
+	rt := &retryTransport{cfg: RetryConfig{MaxRetries: 1, WaitMin: time.Second,
+		WaitMax: 10 * time.Second}}
+	resp := statusResponse(http.StatusServiceUnavailable)
+	resp.Header.Set("Retry-After", "5")
+	if got := rt.backoff(0, resp); got != 5*time.Second {
+		t.Errorf("backoff with Retry-After 5 = %s, want 5s", got)
+	}
+	resp.Header.Set("Retry-After", "120")
+	if got := rt.backoff(0, resp); got != 10*time.Second {
+		t.Errorf("backoff with Retry-After 120 = %s, want capped 10s", got)
+	}
+	for attempt := 0; attempt < 10; attempt++ {
+		got := rt.backoff(attempt, nil)
+		if got < time.Second || got > 10*time.Second {
+			t.Errorf("backoff(%d) = %s, outside [1s, 10s]", attempt, got)
+		}
+	}
+}
+
+// TestRetryClientTimeout: the vanilla client's overall Timeout must leave
+// room for every retry, not only the first attempt.
+func TestRetryClientTimeout(t *testing.T) {
+	if got := (RetryConfig{}).clientTimeout(30 * time.Second); got != 30*time.Second {
+		t.Errorf("no retries: clientTimeout = %s, want 30s", got)
+	}
+	// 5 attempts of 30s and 4 backoffs of up to 30s.
+	if got := DefaultRetryConfig.clientTimeout(30 * time.Second); got != 270*time.Second {
+		t.Errorf("default retries: clientTimeout = %s, want 4m30s", got)
+	}
+}
@@ -0,0 +1,142 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	// sessionTokenHeader carries the session token minted by api/v1/login.
+	sessionTokenHeader = "X-AUTH-TOKEN"
+	// loginPath is never retried after a 401: a rejected login is final.
+	loginPath = "/api/v1/login"
+)
+
+// sessionAuth authenticates every request with a login session token or an
+// OIDC bearer token instead of a static API token.
+//
+// Session tokens expire while long applies (a universe create can take hours)
+// are still polling tasks, so a 401 on a session-authenticated request logs in
+// again and replays the request once. Bearer tokens are passed through as-is;
+// refreshing them is the job of whatever issued them.
+type sessionAuth struct {
+	base        http.RoundTripper
+	login       *VanillaClient
+	email       string
+	password    string
+	bearerToken string
+
+	mu    sync.Mutex
+	token string
+}
+
+func newSessionAuth(base http.RoundTripper, cfg ClientConfig) *sessionAuth {
+	return &sessionAuth{
+		base: base,
+		// Logins go straight to the base transport so a 401 on the login call
+		// itself can never recurse into another login.
+		login: &VanillaClient{
+			Client:      &http.Client{Transport: base},
+			Host:        cfg.Host,
+			EnableHTTPS: cfg.EnableHTTPS,
+			Headers:     cfg.Headers,
+		},
+		email:       cfg.Email,
+		password:    cfg.Password,
+		bearerToken: cfg.BearerToken,
+	}
+}
+
+// currentToken returns the session token in use.
+func (s *sessionAuth) currentToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token
+}
+
+// refresh logs in and stores the new session token. stale is the token the
+// caller saw rejected; if another request already replaced it, the newer
+// token is returned without a second login.
+func (s *sessionAuth) refresh(ctx context.Context, stale string) (*LoginResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && s.token != stale {
+		return &LoginResponse{AuthToken: s.token}, nil
+	}
+	r, err := s.login.Login(ctx, s.email, s.password)
+	if err != nil {
+		return nil, fmt.Errorf("login as %s: %w", s.email, err)
+	}
+	if r.AuthToken == "" {
+		return nil, errors.New("login response did not include a session token")
+	}
+	s.token = r.AuthToken
+	return r, nil
+}
+
+func (s *sessionAuth) authorize(req *http.Request, token string) *http.Request {
+	out := req.Clone(req.Context())
+	if s.bearerToken != "" {
+		out.Header.Set("Authorization", "Bearer "+s.bearerToken)
+		return out
+	}
+	out.Header.Set(sessionTokenHeader, token)
+	return out
+}
+
+// Unwrap returns the wrapped transport.
+func (s *sessionAuth) Unwrap() http.RoundTripper {
+	return s.base
+}
+
+// RoundTrip implements http.RoundTripper.
+func (s *sessionAuth) RoundTrip(req *http.Request) (*http.Response, error) {
+	token := s.currentToken()
+	resp, err := s.base.RoundTrip(s.authorize(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized ||
+		s.bearerToken != "" || strings.HasSuffix(req.URL.Path, loginPath) {
+		return resp, err
+	}
+	// The body was consumed by the first attempt; without GetBody it cannot be
+	// replayed, so the 401 stands.
+	if req.Body != nil && req.GetBody == nil {
+		return resp, err
+	}
+	if _, lerr := s.refresh(req.Context(), token); lerr != nil {
+		// Surface the original 401; ErrorFromHTTPResponse turns it into the
+		// authentication error users expect.
+		return resp, err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	retry := s.authorize(req, s.currentToken())
+	if req.GetBody != nil {
+		body, berr := req.GetBody()
+		if berr != nil {
+			return nil, berr
+		}
+		retry.Body = body
+	}
+	return s.base.RoundTrip(retry)
+}
@@ -0,0 +1,161 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeSessionYBA issues a new session token per login and accepts only the
+// latest one, so expiring a session is a matter of logging in elsewhere.
+type fakeSessionYBA struct {
+	mu     sync.Mutex
+	logins int
+	valid  string
+	bodies []string
+}
+
+func (f *fakeSessionYBA) expire() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.valid = "expired"
+}
+
+func (f *fakeSessionYBA) handler(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	if r.URL.Path == "/api/v1/login" {
+		f.logins++
+		f.valid = fmt.Sprintf("session-%d", f.logins)
+		_, _ = fmt.Fprintf(w, `{"authToken":%q,"customerUUID":"cust","userUUID":"user"}`,
+			f.valid)
+		return
+	}
+	if r.Header.Get(sessionTokenHeader) != f.valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"success":false,"error":"Unable To Authenticate User"}`))
+		return
+	}
+	if r.URL.Path == "/api/v1/session_info" {
+		_, _ = w.Write([]byte(`{"customerUUID":"cust","userUUID":"user"}`))
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	f.bodies = append(f.bodies, string(body))
+	_, _ = w.Write([]byte(`{"taskUUID":"task"}`))
+}
+
+// TestSessionLoginRefreshesExpiredToken: a session that expires mid-apply
+// must be renewed by logging in again, and the rejected request replayed with
+// its body intact.
+func TestSessionLoginRefreshesExpiredToken(t *testing.T) {
+	f := &fakeSessionYBA{}
+	srv := httptest.NewServer(http.HandlerFunc(f.handler))
+	t.Cleanup(srv.Close)
+
+	c, err := NewAPIClient(ClientConfig{
+		Host:     strings.TrimPrefix(srv.URL, "http://"),
+		Email:    "admin@example.com",
+		Password: "secret",
+	})
+	if err != nil {
+		t.Fatalf("NewAPIClient: %v", err)
+	}
+	if c.CustomerID != "cust" {
+		t.Errorf("CustomerID = %q, want cust", c.CustomerID)
+	}
+
+	f.expire()
+	resp, err := c.VanillaClient.makeRequest(context.Background(), http.MethodPost,
+		"api/v1/customers/cust/universes/u/update_lb_config",
+		bytes.NewBufferString(`{"k":"v"}`), c.APIKey)
+	if err != nil {
+		t.Fatalf("makeRequest: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200 after re-login", resp.StatusCode)
+	}
+	if f.logins != 2 {
+		t.Errorf("logins = %d, want 2 (configure + refresh)", f.logins)
+	}
+	if len(f.bodies) != 1 || f.bodies[0] != `{"k":"v"}` {
+		t.Errorf("replayed bodies = %q, want the original body once", f.bodies)
+	}
+}
+
+func TestSessionLoginRejectedCredentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(srv.Close)
+
+	_, err := NewAPIClient(ClientConfig{
+		Host:     strings.TrimPrefix(srv.URL, "http://"),
+		Email:    "admin@example.com",
+		Password: "wrong",
+	})
+	if err == nil {
+		t.Fatal("expected a login error")
+	}
+}
+
+// TestBearerTokenHeader: bearer tokens go out as Authorization headers on
+// every client and never trigger a login.
+func TestBearerTokenHeader(t *testing.T) {
+	var logins int
+	var auth []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/login" {
+			logins++
+		}
+		auth = append(auth, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"customerUUID":"cust"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewAPIClient(ClientConfig{
+		Host:        strings.TrimPrefix(srv.URL, "http://"),
+		BearerToken: "jwt",
+	})
+	if err != nil {
+		t.Fatalf("NewAPIClient: %v", err)
+	}
+	resp, err := c.VanillaClient.makeRequest(
+		context.Background(), http.MethodGet, "api/v1/app_version", nil, c.APIKey)
+	if err != nil {
+		t.Fatalf("makeRequest: %v", err)
+	}
+	_ = resp.Body.Close()
+	if logins != 0 {
+		t.Errorf("logins = %d, want 0 in bearer mode", logins)
+	}
+	for i, a := range auth {
+		if a != "Bearer jwt" {
+			t.Errorf("request %d Authorization = %q, want Bearer jwt", i, a)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RateLimitConfig caps the load the provider puts on YBA. A plan over many
+// universes refreshes them all in parallel, and every refresh fans out into
+// several GETs; without a cap a large state can slow the YBA UI down.
+type RateLimitConfig struct {
+	// RequestsPerSecond spaces requests evenly. Zero means unlimited.
+	RequestsPerSecond float64
+	// MaxConcurrent bounds the requests in flight. Zero means unlimited.
+	MaxConcurrent int
+}
+
+// throttleTransport enforces a RateLimitConfig. It sits directly above the
+// network transport, so every retry attempt and every session login counts
+// against the limits, and one instance is shared by all clients built from
+// the same NewAPIClient call.
+//
+// A concurrency slot is held until the response headers arrive, not until
+// the body is closed: a caller that forgot to close a body must not be able
+// to wedge every other request.
+type throttleTransport struct {
+	base  http.RoundTripper
+	slots chan struct{}
+
+	interval time.Duration
+	mu       sync.Mutex
+	next     time.Time
+}
+
+func newThrottleTransport(base http.RoundTripper, cfg RateLimitConfig) http.RoundTripper {
+	if cfg.RequestsPerSecond <= 0 && cfg.MaxConcurrent <= 0 {
+		return base
+	}
+	t := &throttleTransport{base: base}
+	if cfg.RequestsPerSecond > 0 {
+		t.interval = time.Duration(float64(time.Second) / cfg.RequestsPerSecond)
+	}
+	if cfg.MaxConcurrent > 0 {
+		t.slots = make(chan struct{}, cfg.MaxConcurrent)
+	}
+	return t
+}
+
+// Unwrap returns the wrapped transport.
+func (t *throttleTransport) Unwrap() http.RoundTripper {
+	return t.base
+}
+
+// reserve books the next request slot and returns how long to wait for it.
+func (t *throttleTransport) reserve(now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.next.Before(now) {
+		t.next = now
+	}
+	wait := t.next.Sub(now)
+	t.next = t.next.Add(t.interval)
+	return wait
+}
+
+// release gives back a slot booked by reserve whose request was cancelled
+// while waiting, so it does not delay the requests behind it.
+func (t *throttleTransport) release() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.next = t.next.Add(-t.interval)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *throttleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		default:
+			tflog.Debug(ctx, "Throttling YugabyteDB Anywhere API request", map[string]interface{}{
+				"method": req.Method,
+				"path":   req.URL.Path,
+				"reason": "max_concurrent_requests",
+				"limit":  cap(t.slots),
+			})
+			select {
+			case t.slots <- struct{}{}:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		defer func() { <-t.slots }()
+	}
+
+	if t.interval > 0 {
+		if wait := t.reserve(time.Now()); wait > 0 {
+			tflog.Debug(ctx, "Throttling YugabyteDB Anywhere API request", map[string]interface{}{
+				"method": req.Method,
+				"path":   req.URL.Path,
+				"reason": "max_requests_per_second",
+				"wait":   wait.String(),
+			})
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				t.release()
+				return nil, ctx.Err()
+			}
+		}
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,141 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestThrottleMaxConcurrent(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	rt := newThrottleTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		inFlight.Add(-1)
+		return statusResponse(http.StatusOK), nil
+	}), RateLimitConfig{MaxConcurrent: 2})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest(http.MethodGet, "http://yba/api/v1/x", nil)
+			resp, err := rt.RoundTrip(req)
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+	if got := peak.Load(); got != 2 {
+		t.Errorf("peak concurrency = %d, want 2", got)
+	}
+}
+
+func TestThrottleRequestsPerSecond(t *testing.T) {
+	rt := newThrottleTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return statusResponse(http.StatusOK), nil
+	}), RateLimitConfig{RequestsPerSecond: 50})
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "http://yba/api/v1/x", nil)
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("RoundTrip: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+	// The first request goes out at once, the other five 20ms apart.
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("6 requests at 50/s took %s, want at least 100ms", elapsed)
+	}
+}
+
+func TestThrottleHonorsCancel(t *testing.T) {
+	block := make(chan struct{})
+	rt := newThrottleTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		<-block
+		return statusResponse(http.StatusOK), nil
+	}), RateLimitConfig{MaxConcurrent: 1})
+	defer close(block)
+
+	go func() {
+		req, _ := http.NewRequest(http.MethodGet, "http://yba/api/v1/x", nil)
+		_, _ = rt.RoundTrip(req) //nolint:bodyclose // stub response
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://yba/api/v1/x", nil)
+	if _, err := rt.RoundTrip(req); !errors.Is(err, context.DeadlineExceeded) { //nolint:bodyclose
+		t.Errorf("err = %v, want context.DeadlineExceeded while waiting for a slot", err)
+	}
+}
+
+func TestThrottleCancelReleasesRateSlot(t *testing.T) {
+	rt := newThrottleTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return statusResponse(http.StatusOK), nil
+	}), RateLimitConfig{RequestsPerSecond: 10})
+
+	req, _ := http.NewRequest(http.MethodGet, "http://yba/api/v1/x", nil)
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	// The second request gives up while waiting for its slot 100ms out.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, "http://yba/api/v1/x", nil)
+	if _, err := rt.RoundTrip(req); !errors.Is(err, context.DeadlineExceeded) { //nolint:bodyclose
+		t.Fatalf("err = %v, want context.DeadlineExceeded while waiting for the rate", err)
+	}
+
+	// The third request takes the freed slot instead of queueing behind it.
+	start := time.Now()
+	req, _ = http.NewRequest(http.MethodGet, "http://yba/api/v1/x", nil)
+	resp, err = rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	_ = resp.Body.Close()
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("request after a cancelled wait took %s, want under 150ms", elapsed)
+	}
+}
+
+func TestThrottleDisabled(t *testing.T) {
+	base := roundTripFunc(func(*http.Request) (*http.Response, error) { return nil, nil })
+	if _, ok := newThrottleTransport(base, RateLimitConfig{}).(*throttleTransport); ok {
+		t.Error("zero limits must not wrap the transport")
+	}
+}
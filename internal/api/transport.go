@@ -0,0 +1,249 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// TLSConfig holds the provider's settings for verifying the YBA server
+// certificate.
+type TLSConfig struct {
+	// CACertFile is a path to a PEM bundle of CAs trusted in addition to the
+	// system roots.
+	CACertFile string
+	// CACertPEM is an inline PEM bundle, appended to the same pool as
+	// CACertFile.
+	CACertPEM string
+	// ServerName overrides the name checked against the server certificate,
+	// for YBA reached through an IP or a tunnel.
+	ServerName string
+	// InsecureSkipVerify disables server certificate verification entirely.
+	InsecureSkipVerify bool
+
+	// ClientCertFile and ClientKeyFile are paths to the PEM certificate and
+	// key presented to ingresses that require mutual TLS.
+	ClientCertFile string
+	ClientKeyFile  string
+	// ClientCertPEM and ClientKeyPEM are the inline equivalents of
+	// ClientCertFile and ClientKeyFile.
+	ClientCertPEM string
+	ClientKeyPEM  string
+}
+
+// HasCA reports whether a custom CA bundle is configured.
+func (t TLSConfig) HasCA() bool {
+	return t.CACertFile != "" || t.CACertPEM != ""
+}
+
+// clientCertificate loads the mutual TLS key pair, if one is configured.
+func (t TLSConfig) clientCertificate() (*tls.Certificate, error) {
+	certPEM, keyPEM := []byte(t.ClientCertPEM), []byte(t.ClientKeyPEM)
+	if t.ClientCertFile != "" {
+		b, err := os.ReadFile(t.ClientCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading client_cert_file %s: %w", t.ClientCertFile, err)
+		}
+		certPEM = b
+	}
+	if t.ClientKeyFile != "" {
+		b, err := os.ReadFile(t.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading client_key_file %s: %w", t.ClientKeyFile, err)
+		}
+		keyPEM = b
+	}
+	if len(certPEM) == 0 && len(keyPEM) == 0 {
+		return nil, nil
+	}
+	if len(certPEM) == 0 || len(keyPEM) == 0 {
+		return nil, errors.New("a client certificate and a client key must be " +
+			"configured together")
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("loading client certificate: %w", err)
+	}
+	return &cert, nil
+}
+
+// build returns the *tls.Config for t. Custom CAs are added on top of the
+// system pool so a YBA behind a publicly-signed ingress keeps working when a
+// private CA is also listed.
+func (t TLSConfig) build() (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: t.ServerName,
+	}
+	clientCert, err := t.clientCertificate()
+	if err != nil {
+		return nil, err
+	}
+	if clientCert != nil {
+		cfg.Certificates = []tls.Certificate{*clientCert}
+	}
+	if t.InsecureSkipVerify {
+		cfg.InsecureSkipVerify = true //nolint:gosec // explicit opt-in via insecure_skip_verify
+		return cfg, nil
+	}
+	if !t.HasCA() {
+		return cfg, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if t.CACertFile != "" {
+		pem, err := os.ReadFile(t.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading ca_cert_file %s: %w", t.CACertFile, err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("ca_cert_file %s contains no PEM certificates",
+				t.CACertFile)
+		}
+	}
+	if t.CACertPEM != "" {
+		if !pool.AppendCertsFromPEM([]byte(t.CACertPEM)) {
+			return nil, errors.New("ca_cert_pem contains no PEM certificates")
+		}
+	}
+	cfg.RootCAs = pool
+	return cfg, nil
+}
+
+// ProxyConfig holds the provider's HTTP(S) proxy settings.
+type ProxyConfig struct {
+	// URL is the proxy used for both HTTP and HTTPS requests to YBA. Empty
+	// falls back to the HTTPS_PROXY/HTTP_PROXY environment variables.
+	URL string
+	// NoProxy lists hosts that bypass the proxy, in NO_PROXY syntax. Empty
+	// falls back to the NO_PROXY environment variable.
+	NoProxy string
+	// ConnectHeaders are sent on the CONNECT request that opens an HTTPS
+	// tunnel through the proxy.
+	ConnectHeaders map[string]string
+}
+
+// proxyFunc returns the transport's Proxy function for p.
+func (p ProxyConfig) proxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	cfg := httpproxy.FromEnvironment()
+	if p.URL != "" {
+		if _, err := url.Parse(p.URL); err != nil {
+			return nil, fmt.Errorf("invalid proxy_url: %w", err)
+		}
+		cfg.HTTPProxy = p.URL
+		cfg.HTTPSProxy = p.URL
+	}
+	if p.NoProxy != "" {
+		cfg.NoProxy = p.NoProxy
+	}
+	proxy := cfg.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}, nil
+}
+
+// newTransport builds the single transport shared by the v1, v2 and vanilla
+// clients.
+//
+// It bounds connection setup and time-to-first-byte. Without these, a
+// connection that dies without an RST (a black-holed load balancer or tunnel)
+// hangs its request forever — GetSessionInfo runs on context.Background(), so
+// provider configure would never return. No overall client Timeout on the
+// generated clients: response bodies of any size stay unbounded.
+//
+// The transport is always private to the provider; http.DefaultTransport is
+// never modified.
+func newTransport(t TLSConfig, p ProxyConfig) (*http.Transport, error) {
+	tlsConfig, err := t.build()
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := p.proxyFunc()
+	if err != nil {
+		return nil, err
+	}
+	var connectHeader http.Header
+	if len(p.ConnectHeaders) > 0 {
+		connectHeader = make(http.Header, len(p.ConnectHeaders))
+		for k, v := range p.ConnectHeaders {
+			connectHeader.Set(k, v)
+		}
+	}
+	return &http.Transport{
+		Proxy:                 proxy,
+		ProxyConnectHeader:    connectHeader,
+		TLSClientConfig:       tlsConfig,
+		DialContext:           (&net.Dialer{Timeout: 30 * time.Second}).DialContext,
+		TLSHandshakeTimeout:   30 * time.Second,
+		ResponseHeaderTimeout: 2 * time.Minute,
+		IdleConnTimeout:       90 * time.Second,
+		MaxIdleConns:          100,
+	}, nil
+}
+
+// sensitiveHeaderMarkers are case-insensitive fragments of header names whose
+// values are credentials.
+var sensitiveHeaderMarkers = []string{
+	"authorization", "cookie", "token", "secret", "password", "key", "auth",
+}
+
+// IsSensitiveHeader reports whether a header carries a credential and must
+// not be logged.
+func IsSensitiveHeader(name string) bool {
+	lower := strings.ToLower(name)
+	for _, m := range sensitiveHeaderMarkers {
+		if strings.Contains(lower, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactHeaders returns a copy of headers safe for logging: values of
+// sensitive headers are replaced.
+func RedactHeaders(headers map[string]string) map[string]string {
+	out := make(map[string]string, len(headers))
+	for k, v := range headers {
+		if IsSensitiveHeader(k) {
+			v = "<redacted>"
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// RedactURL returns u with any password in its user info replaced.
+func RedactURL(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return "<unparseable>"
+	}
+	return parsed.Redacted()
+}
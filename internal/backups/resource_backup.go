@@ -238,6 +238,7 @@ func ResourceBackup() *schema.Resource {
 					},
 				},
 			},
+			utils.PendingTaskUUIDKey: utils.PendingTaskUUIDSchema(),
 		},
 	}
 }
@@ -266,6 +267,7 @@ func resourceBackupCreate(
 
 	c := meta.(*api.APIClient).YugawareClient
 	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
 
 	// Build keyspace table list
 	// Empty keyspaceTableList = full universe backup (all databases/keyspaces)
@@ -329,8 +331,9 @@ func resourceBackupCreate(
 	tflog.Info(ctx, fmt.Sprintf("Creating on-demand backup for universe %s", req.UniverseUUID))
 
 	var taskUUID string
-	if diags := utils.DispatchAndWait(ctx, "Create Backup", cUUID, c,
-		d.Timeout(schema.TimeoutCreate),
+	universeUUID := d.Get("universe_uuid").(string)
+	diags := utils.DispatchAndWait(utils.TrackPendingTask(ctx, d), "Create Backup", cUUID, c,
+		d.Timeout(schema.TimeoutCreate), abortOnCancel,
 		utils.ResourceEntity, "Backup", "Create",
 		func() (string, *http.Response, error) {
 			r, resp, createErr := c.BackupsAPI.Createbackup(ctx, cUUID).Backup(req).Execute()
@@ -340,12 +343,15 @@ func resourceBackupCreate(
 			taskUUID = r.GetTaskUUID()
 			return taskUUID, resp, nil
 		},
-	); diags != nil {
+	)
+	if utils.PendingTaskUUID(d) != "" {
+		return recordInterruptedBackup(ctx, d, c, cUUID, universeUUID, taskUUID, diags)
+	}
+	if diags != nil {
 		return diags
 	}
 
 	// Find the backup UUID from the task
-	universeUUID := d.Get("universe_uuid").(string)
 	backupUUID, err := findBackupUUIDFromTask(ctx, c, cUUID, universeUUID, taskUUID)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to find backup UUID: %w", err))
@@ -366,9 +372,16 @@ func resourceBackupRead(
 
 	c := meta.(*api.APIClient).YugawareClient
 	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
 
 	backupUUID := d.Id()
 
+	diags = append(diags, utils.ResumePendingTask(ctx, d, cUUID, c,
+		d.Timeout(schema.TimeoutCreate), abortOnCancel)...)
+	if diags.HasError() {
+		return diags
+	}
+
 	// Get backup details
 	backup, response, err := c.BackupsAPI.GetBackupV2(ctx, cUUID, backupUUID).Execute()
 	if err != nil {
@@ -561,6 +574,28 @@ func isBackupGone(response *http.Response, err error) bool {
 	return false
 }
 
+// recordInterruptedBackup keeps a backup whose create was interrupted in
+// state, so the next run waits for its task. YBA adds the backup row once the
+// task starts; if it is not there yet the backup cannot be tracked and the
+// interruption is reported as an error.
+func recordInterruptedBackup(
+	ctx context.Context,
+	d *schema.ResourceData,
+	c *client.APIClient,
+	cUUID, universeUUID, taskUUID string,
+	diags diag.Diagnostics,
+) diag.Diagnostics {
+	lookupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+	defer cancel()
+	backupUUID, err := findBackupUUIDFromTask(lookupCtx, c, cUUID, universeUUID, taskUUID)
+	if err != nil {
+		return append(diags, diag.Errorf("backup task %s is still running but its "+
+			"backup could not be recorded in state: %v", taskUUID, err)...)
+	}
+	d.SetId(backupUUID)
+	return diags
+}
+
 // findBackupUUIDFromTask retrieves the backup UUID created by a backup task
 func findBackupUUIDFromTask(
 	ctx context.Context,
@@ -419,6 +419,7 @@ func resourceBackupsCreate(
 	meta interface{}) diag.Diagnostics {
 	c := meta.(*api.APIClient).YugawareClient
 	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
 
 	if d.Get("schedule_name").(string) == "" {
 		return diag.FromErr(errors.New("V2 Schedules require a name"))
@@ -552,7 +553,7 @@ func resourceBackupsCreate(
 
 	var scheduleUUID string
 	if diags := utils.DispatchAndWait(ctx, "Create Backup Schedule", cUUID, c,
-		d.Timeout(schema.TimeoutCreate),
+		d.Timeout(schema.TimeoutCreate), abortOnCancel,
 		utils.ResourceEntity, "Backup Schedule", "Create",
 		func() (string, *http.Response, error) {
 			r, resp, err := c.BackupsAPI.CreateBackupScheduleAsync(ctx, cUUID).Backup(req).Execute()
@@ -824,6 +825,7 @@ func resourceBackupsUpdate(
 	meta interface{}) diag.Diagnostics {
 	c := meta.(*api.APIClient).YugawareClient
 	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
 	universeUUID := d.Get("universe_uuid").(string)
 
 	var err error
@@ -941,7 +943,7 @@ func resourceBackupsUpdate(
 		tflog.Info(ctx, fmt.Sprintf("Updating backup schedule %s", d.Id()))
 
 		if diags := utils.DispatchAndWait(ctx, "Edit Backup Schedule", cUUID, c,
-			d.Timeout(schema.TimeoutUpdate),
+			d.Timeout(schema.TimeoutUpdate), abortOnCancel,
 			utils.ResourceEntity, "Backup Schedule", "Update",
 			func() (string, *http.Response, error) {
 				r, resp, err := c.ScheduleManagementAPI.EditBackupScheduleAsync(
@@ -964,6 +966,7 @@ func resourceBackupsDelete(
 	meta interface{}) diag.Diagnostics {
 	c := meta.(*api.APIClient).YugawareClient
 	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
 
 	if d.Get("delete_backup").(bool) {
 		backupsList, response, err := c.BackupsAPI.ListOfBackups(ctx, cUUID,
@@ -1014,7 +1017,7 @@ func resourceBackupsDelete(
 	tflog.Info(ctx, fmt.Sprintf("Deleting backup schedule %s", d.Id()))
 
 	if diags := utils.DispatchAndWait(ctx, "Delete Backup Schedule", cUUID, c,
-		d.Timeout(schema.TimeoutDelete),
+		d.Timeout(schema.TimeoutDelete), abortOnCancel,
 		utils.ResourceEntity, "Backup Schedule", "Delete",
 		func() (string, *http.Response, error) {
 			r, resp, err := c.ScheduleManagementAPI.DeleteBackupScheduleAsync(
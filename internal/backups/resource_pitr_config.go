@@ -0,0 +1,341 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backups
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/api"
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+// ResourcePITRConfig manages the point-in-time recovery snapshot schedule of
+// a database or keyspace
+func ResourcePITRConfig() *schema.Resource {
+	return &schema.Resource{
+		Description: "Point-in-time recovery (PITR) configuration for a YSQL database or " +
+			"YCQL keyspace of a universe. YugabyteDB Anywhere takes snapshots of the " +
+			"namespace at the snapshot interval and keeps them for the retention period, " +
+			"so the namespace can be restored to any time in that window with " +
+			"yba_pitr_restore.",
+
+		CreateContext: resourcePITRConfigCreate,
+		ReadContext:   resourcePITRConfigRead,
+		UpdateContext: resourcePITRConfigUpdate,
+		DeleteContext: resourcePITRConfigDelete,
+
+		CustomizeDiff: validatePITRIntervalDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePITRConfigImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"universe_uuid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "UUID of the universe.",
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the YSQL database or YCQL keyspace.",
+			},
+			"table_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(
+					[]string{"PGSQL_TABLE_TYPE", "YQL_TABLE_TYPE"}, false)),
+				Description: "Table type of the namespace: PGSQL_TABLE_TYPE for a YSQL " +
+					"database, YQL_TABLE_TYPE for a YCQL keyspace.",
+			},
+			"retention_period_in_seconds": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description: "How long snapshots are kept, which bounds how far back the " +
+					"namespace can be restored.",
+			},
+			"snapshot_interval_in_seconds": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          86400,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description: "Interval between snapshots. Must be shorter than the " +
+					"retention period. Default: 86400 (1 day).",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name YugabyteDB Anywhere gave the PITR configuration.",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "State of the snapshot schedule (e.g., ACTIVE).",
+			},
+			"min_recover_time_in_millis": {
+				Type:     schema.TypeInt,
+				Computed: true,
+				Description: "Earliest time, in milliseconds since the epoch, the namespace " +
+					"can be restored to.",
+			},
+			"max_recover_time_in_millis": {
+				Type:     schema.TypeInt,
+				Computed: true,
+				Description: "Latest time, in milliseconds since the epoch, the namespace " +
+					"can be restored to.",
+			},
+		},
+	}
+}
+
+// validatePITRIntervalDiff rejects a snapshot interval that is not shorter than
+// the retention period at plan time, instead of leaving it to YBA at apply.
+func validatePITRIntervalDiff(
+	ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("retention_period_in_seconds") ||
+		!d.NewValueKnown("snapshot_interval_in_seconds") {
+		return nil
+	}
+	retention := d.Get("retention_period_in_seconds").(int)
+	interval := d.Get("snapshot_interval_in_seconds").(int)
+	if interval >= retention {
+		return fmt.Errorf("snapshot_interval_in_seconds (%d) must be shorter than "+
+			"retention_period_in_seconds (%d)", interval, retention)
+	}
+	return nil
+}
+
+// findPITRConfig finds a universe's PITR config by UUID, or by namespace and
+// table type when pitrUUID is empty.
+func findPITRConfig(
+	ctx context.Context,
+	c *client.APIClient,
+	cUUID, universeUUID, pitrUUID, namespace, tableType string,
+) (*client.PitrConfig, *http.Response, error) {
+	configs, response, err := c.PITRManagementAPI.ListOfPitrConfigs(ctx, cUUID, universeUUID).
+		Execute()
+	if err != nil {
+		return nil, response, err
+	}
+	for i := range configs {
+		config := &configs[i]
+		if pitrUUID != "" && config.GetUuid() == pitrUUID {
+			return config, response, nil
+		}
+		if pitrUUID == "" && config.GetDbName() == namespace &&
+			config.GetTableType() == tableType {
+			return config, response, nil
+		}
+	}
+	identifier := pitrUUID
+	if identifier == "" {
+		identifier = namespace
+	}
+	return nil, response, utils.ResourceNotFoundError("PITR config", identifier)
+}
+
+func resourcePITRConfigCreate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+
+	universeUUID := d.Get("universe_uuid").(string)
+	namespace := d.Get("namespace").(string)
+	tableType := d.Get("table_type").(string)
+	req := client.CreatePitrConfigParams{
+		RetentionPeriodInSeconds: int64(d.Get("retention_period_in_seconds").(int)),
+		IntervalInSeconds: utils.GetInt64Pointer(
+			int64(d.Get("snapshot_interval_in_seconds").(int))),
+	}
+
+	if diags := utils.DispatchAndWait(ctx, "Create PITR Config", cUUID, c,
+		d.Timeout(schema.TimeoutCreate), abortOnCancel,
+		utils.ResourceEntity, "PITR Config", "Create",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.PITRManagementAPI.CreatePitrConfig(
+				ctx, cUUID, universeUUID, tableType, namespace).PitrConfig(req).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			return r.GetTaskUUID(), resp, nil
+		},
+	); diags != nil {
+		return diags
+	}
+
+	// The task reports the universe as its resource; the config is found by
+	// its namespace.
+	config, response, err := findPITRConfig(ctx, c, cUUID, universeUUID, "", namespace,
+		tableType)
+	if err != nil {
+		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"PITR Config", "Create - Find created config")
+		return diag.FromErr(errMessage)
+	}
+	d.SetId(config.GetUuid())
+	tflog.Info(ctx, fmt.Sprintf("Created PITR config %s for %s", d.Id(), namespace))
+	return resourcePITRConfigRead(ctx, d, meta)
+}
+
+func resourcePITRConfigRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+
+	config, response, err := findPITRConfig(ctx, c, cUUID, d.Get("universe_uuid").(string),
+		d.Id(), "", "")
+	if err != nil {
+		if utils.IsResourceNotFoundError(err) || utils.IsHTTPNotFound(response) ||
+			utils.IsHTTPBadRequestNotFound(response) {
+			tflog.Warn(ctx, fmt.Sprintf("PITR config %s not found, removing from state: %v",
+				d.Id(), err))
+			d.SetId("")
+			return nil
+		}
+		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"PITR Config", "Read")
+		return diag.FromErr(errMessage)
+	}
+
+	if err = d.Set("namespace", config.GetDbName()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("table_type", config.GetTableType()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("retention_period_in_seconds", config.GetRetentionPeriod()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("snapshot_interval_in_seconds", config.GetScheduleInterval()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("name", config.GetName()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("state", config.GetState()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("min_recover_time_in_millis", config.GetMinRecoverTimeInMillis()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("max_recover_time_in_millis", config.GetMaxRecoverTimeInMillis()); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourcePITRConfigUpdate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) (diags diag.Diagnostics) {
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+
+	defer func() {
+		diags = append(resourcePITRConfigRead(ctx, d, meta), diags...)
+	}()
+
+	if !d.HasChanges("retention_period_in_seconds", "snapshot_interval_in_seconds") {
+		return nil
+	}
+	req := client.UpdatePitrConfigParams{
+		RetentionPeriodInSeconds: int64(d.Get("retention_period_in_seconds").(int)),
+		IntervalInSeconds:        int64(d.Get("snapshot_interval_in_seconds").(int)),
+	}
+	return utils.DispatchAndWait(ctx, "Update PITR Config", cUUID, c,
+		d.Timeout(schema.TimeoutUpdate), abortOnCancel,
+		utils.ResourceEntity, "PITR Config", "Update",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.PITRManagementAPI.UpdatePitrConfig(
+				ctx, cUUID, d.Get("universe_uuid").(string), d.Id()).PitrConfig(req).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			return r.GetTaskUUID(), resp, nil
+		},
+	)
+}
+
+func resourcePITRConfigDelete(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+
+	if diags := utils.DispatchAndWait(ctx, "Delete PITR Config", cUUID, c,
+		d.Timeout(schema.TimeoutDelete), abortOnCancel,
+		utils.ResourceEntity, "PITR Config", "Delete",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.PITRManagementAPI.DeletePitrConfig(
+				ctx, cUUID, d.Get("universe_uuid").(string), d.Id()).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			return r.GetTaskUUID(), resp, nil
+		},
+	); diags != nil {
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourcePITRConfigImport takes "<universe-uuid>/<pitr-config-uuid>": PITR
+// configs are listed per universe.
+func resourcePITRConfigImport(
+	_ context.Context, d *schema.ResourceData, _ interface{},
+) ([]*schema.ResourceData, error) {
+	universeUUID, pitrUUID, ok := strings.Cut(d.Id(), "/")
+	if !ok || universeUUID == "" || pitrUUID == "" {
+		return nil, fmt.Errorf("invalid import ID %q: expected %q", d.Id(),
+			"<universe-uuid>/<pitr-config-uuid>")
+	}
+	if err := d.Set("universe_uuid", universeUUID); err != nil {
+		return nil, err
+	}
+	d.SetId(pitrUUID)
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,165 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backups
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+func testPITRConfig(uuid, dbName, tableType string) client.PitrConfig {
+	var config client.PitrConfig
+	config.SetUuid(uuid)
+	config.SetDbName(dbName)
+	config.SetTableType(tableType)
+	return config
+}
+
+// newFakePITRClient serves configs as the PITR config list of every universe.
+func newFakePITRClient(t *testing.T, configs []client.PitrConfig) *client.APIClient {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(configs)
+	}))
+	t.Cleanup(srv.Close)
+	cfg := client.NewConfiguration()
+	cfg.Scheme = "http"
+	cfg.Host = srv.Listener.Addr().String()
+	return client.NewAPIClient(cfg)
+}
+
+func TestFindPITRConfig(t *testing.T) {
+	c := newFakePITRClient(t, []client.PitrConfig{
+		testPITRConfig("p1", "app", "PGSQL_TABLE_TYPE"),
+		testPITRConfig("p2", "app", "YQL_TABLE_TYPE"),
+	})
+	ctx := context.Background()
+
+	config, _, err := findPITRConfig(ctx, c, "cust", "uni", "p2", "", "")
+	if err != nil || config.GetUuid() != "p2" {
+		t.Errorf("findPITRConfig(p2) = %v, %v, want p2", config, err)
+	}
+	// Without a UUID the table type tells apart a database and a keyspace of
+	// the same name.
+	config, _, err = findPITRConfig(ctx, c, "cust", "uni", "", "app", "YQL_TABLE_TYPE")
+	if err != nil || config.GetUuid() != "p2" {
+		t.Errorf("findPITRConfig(app, YQL) = %v, %v, want p2", config, err)
+	}
+	config, _, err = findPITRConfig(ctx, c, "cust", "uni", "", "app", "PGSQL_TABLE_TYPE")
+	if err != nil || config.GetUuid() != "p1" {
+		t.Errorf("findPITRConfig(app, PGSQL) = %v, %v, want p1", config, err)
+	}
+
+	_, _, err = findPITRConfig(ctx, c, "cust", "uni", "gone", "", "")
+	if !utils.IsResourceNotFoundError(err) {
+		t.Errorf("findPITRConfig(gone) err = %v, want a not-found error", err)
+	}
+	_, _, err = findPITRConfig(ctx, c, "cust", "uni", "", "billing", "PGSQL_TABLE_TYPE")
+	if !utils.IsResourceNotFoundError(err) {
+		t.Errorf("findPITRConfig(billing) err = %v, want a not-found error", err)
+	}
+}
+
+func TestRestoreTimeMillis(t *testing.T) {
+	config := testPITRConfig("p1", "app", "PGSQL_TABLE_TYPE")
+	// 2026-10-01T00:00:00Z to 2026-10-02T00:00:00Z.
+	config.SetMinRecoverTimeInMillis(1790812800000)
+	config.SetMaxRecoverTimeInMillis(1790899200000)
+
+	millis, err := restoreTimeMillis("2026-10-01T12:00:00Z", &config)
+	if err != nil || millis != 1790856000000 {
+		t.Errorf("restoreTimeMillis(inside) = %d, %v, want 1790856000000", millis, err)
+	}
+	for _, restoreTime := range []string{"2026-09-30T23:59:59Z", "2026-10-02T00:00:01Z"} {
+		if _, err := restoreTimeMillis(restoreTime, &config); err == nil ||
+			!strings.Contains(err.Error(), "outside the recovery window") {
+			t.Errorf("restoreTimeMillis(%s) err = %v, want outside the recovery window",
+				restoreTime, err)
+		}
+	}
+	if _, err := restoreTimeMillis("yesterday", &config); err == nil {
+		t.Error("restoreTimeMillis(yesterday) succeeded, want a parse error")
+	}
+}
+
+func TestResourcePITRConfigImport(t *testing.T) {
+	cases := []struct {
+		id       string
+		universe string
+		pitr     string
+		wantErr  bool
+	}{
+		{id: "uni-1/pitr-1", universe: "uni-1", pitr: "pitr-1"},
+		{id: "pitr-1", wantErr: true},
+		{id: "uni-1/", wantErr: true},
+		{id: "/pitr-1", wantErr: true},
+	}
+	for _, tc := range cases {
+		d := schema.TestResourceDataRaw(t, ResourcePITRConfig().Schema, map[string]interface{}{})
+		d.SetId(tc.id)
+		got, err := resourcePITRConfigImport(context.Background(), d, nil)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("import %q succeeded, want an error", tc.id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("import %q: %v", tc.id, err)
+		}
+		if len(got) != 1 || got[0].Id() != tc.pitr ||
+			got[0].Get("universe_uuid").(string) != tc.universe {
+			t.Errorf("import %q = id %q universe %q, want %q and %q", tc.id, got[0].Id(),
+				got[0].Get("universe_uuid"), tc.pitr, tc.universe)
+		}
+	}
+}
+
+func TestValidatePITRIntervalDiff(t *testing.T) {
+	cases := []struct {
+		retention, interval int
+		wantErr             bool
+	}{
+		{retention: 604800, interval: 86400},
+		{retention: 86400, interval: 86400, wantErr: true},
+		{retention: 3600, interval: 86400, wantErr: true},
+	}
+	for _, tc := range cases {
+		_, err := ResourcePITRConfig().Diff(context.Background(), nil,
+			terraform.NewResourceConfigRaw(map[string]interface{}{
+				"universe_uuid":                "uni-1",
+				"namespace":                    "app",
+				"table_type":                   "PGSQL_TABLE_TYPE",
+				"retention_period_in_seconds":  tc.retention,
+				"snapshot_interval_in_seconds": tc.interval,
+			}), nil)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("retention %d, interval %d: err = %v, want error %v", tc.retention,
+				tc.interval, err, tc.wantErr)
+		}
+	}
+}
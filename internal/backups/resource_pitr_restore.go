@@ -0,0 +1,202 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backups
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/api"
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+// ResourcePITRRestore restores a namespace to a point in time
+func ResourcePITRRestore() *schema.Resource {
+	return &schema.Resource{
+		Description: "Restore the namespace of a yba_pitr_config to a point in time. The " +
+			"restore runs when the resource is created and again, in place, whenever " +
+			"restore_time changes; the apply waits for it to complete. Destroying the " +
+			"resource only removes it from state.",
+
+		CreateContext: resourcePITRRestoreCreate,
+		ReadContext:   resourcePITRRestoreRead,
+		UpdateContext: resourcePITRRestoreUpdate,
+		DeleteContext: resourcePITRRestoreDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"universe_uuid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "UUID of the universe.",
+			},
+			"pitr_config_uuid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "UUID of the yba_pitr_config of the namespace to restore.",
+			},
+			"restore_time": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+				Description: "RFC3339 timestamp to restore the namespace to, e.g. " +
+					"2026-10-01T12:00:00Z. Must fall within the recovery window " +
+					"reported by the PITR config. Changing it restores again.",
+			},
+			"task_uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "UUID of the YugabyteDB Anywhere task of the last restore.",
+			},
+			utils.PendingTaskUUIDKey: utils.PendingTaskUUIDSchema(),
+		},
+	}
+}
+
+// restoreTimeMillis parses restore_time and checks it against the recovery
+// window of the PITR config.
+func restoreTimeMillis(restoreTime string, config *client.PitrConfig) (int64, error) {
+	t, err := time.Parse(time.RFC3339, restoreTime)
+	if err != nil {
+		return 0, fmt.Errorf("restore_time: %w", err)
+	}
+	millis := t.UnixMilli()
+	minMillis, maxMillis := config.GetMinRecoverTimeInMillis(), config.GetMaxRecoverTimeInMillis()
+	if millis < minMillis || millis > maxMillis {
+		return 0, fmt.Errorf("restore_time %s is outside the recovery window of PITR config "+
+			"%s: %s to %s", restoreTime, config.GetUuid(),
+			time.UnixMilli(minMillis).UTC().Format(time.RFC3339),
+			time.UnixMilli(maxMillis).UTC().Format(time.RFC3339))
+	}
+	return millis, nil
+}
+
+// performPITRRestore restores the namespace to restore_time and waits for it.
+func performPITRRestore(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{},
+	timeout time.Duration,
+) diag.Diagnostics {
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+
+	universeUUID := d.Get("universe_uuid").(string)
+	pitrUUID := d.Get("pitr_config_uuid").(string)
+	config, response, err := findPITRConfig(ctx, c, cUUID, universeUUID, pitrUUID, "", "")
+	if err != nil {
+		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"PITR Restore", "Fetch PITR config")
+		return diag.FromErr(errMessage)
+	}
+	millis, err := restoreTimeMillis(d.Get("restore_time").(string), config)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := client.RestoreSnapshotScheduleParams{
+		PitrConfigUUID:      pitrUUID,
+		RestoreTimeInMillis: millis,
+	}
+	tflog.Info(ctx, "Restoring namespace to a point in time", map[string]interface{}{
+		"universe_uuid":    universeUUID,
+		"pitr_config_uuid": pitrUUID,
+		"namespace":        config.GetDbName(),
+		"restore_time":     d.Get("restore_time").(string),
+	})
+	return utils.DispatchAndWait(utils.TrackPendingTask(ctx, d), "PITR Restore", cUUID, c,
+		timeout, abortOnCancel,
+		utils.ResourceEntity, "PITR Restore", "Restore",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.PITRManagementAPI.PerformPitr(ctx, cUUID, universeUUID).
+				PerformPitr(req).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			if d.Id() == "" {
+				d.SetId(pitrUUID)
+			}
+			if err := d.Set("task_uuid", r.GetTaskUUID()); err != nil {
+				return "", resp, err
+			}
+			return r.GetTaskUUID(), resp, nil
+		},
+	)
+}
+
+func resourcePITRRestoreCreate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	if diags := performPITRRestore(ctx, d, meta, d.Timeout(schema.TimeoutCreate)); diags != nil {
+		return diags
+	}
+	return resourcePITRRestoreRead(ctx, d, meta)
+}
+
+func resourcePITRRestoreRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	// A restore leaves nothing to read back; only a restore an interrupted
+	// apply left running is waited for.
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+	return utils.ResumePendingTask(ctx, d, cUUID, c, d.Timeout(schema.TimeoutUpdate), abortOnCancel)
+}
+
+func resourcePITRRestoreUpdate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	if !d.HasChange("restore_time") {
+		return resourcePITRRestoreRead(ctx, d, meta)
+	}
+	if diags := performPITRRestore(ctx, d, meta, d.Timeout(schema.TimeoutUpdate)); diags != nil {
+		// Keep the previous restore_time so the next apply restores again,
+		// unless the restore is still running and the next Read waits for it.
+		if diags.HasError() && utils.PendingTaskUUID(d) == "" {
+			utils.RevertFields(d, "restore_time")
+		}
+		return diags
+	}
+	return resourcePITRRestoreRead(ctx, d, meta)
+}
+
+func resourcePITRRestoreDelete(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	// Restores cannot be undone; the resource is only removed from state.
+	d.SetId("")
+	return nil
+}
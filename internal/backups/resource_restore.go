@@ -244,6 +244,7 @@ func ResourceRestore() *schema.Resource {
 				Description: "Restore to a specific point in time (Unix timestamp in milliseconds). " +
 					"Used for Point-in-Time Recovery (PITR).",
 			},
+			utils.PendingTaskUUIDKey: utils.PendingTaskUUIDSchema(),
 		},
 	}
 }
@@ -351,6 +352,7 @@ func resourceRestoreCreate(
 	meta interface{}) diag.Diagnostics {
 	c := meta.(*api.APIClient).YugawareClient
 	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
 
 	// Build backup storage info list
 	backupStorageInfoList := make([]client.BackupStorageInfo, 0)
@@ -427,24 +429,23 @@ func resourceRestoreCreate(
 	}
 
 	// Execute restore, retrying on 409 universe-task conflicts.
-	var taskUUID string
-	if diags := utils.DispatchAndWait(ctx, "Create Restore", cUUID, c,
-		d.Timeout(schema.TimeoutCreate),
+	// The ID is the task UUID since restores don't have a persistent ID. It is
+	// set before waiting so an interrupted restore stays in state with its
+	// pending task, instead of being run a second time.
+	if diags := utils.DispatchAndWait(utils.TrackPendingTask(ctx, d), "Create Restore", cUUID, c,
+		d.Timeout(schema.TimeoutCreate), abortOnCancel,
 		utils.ResourceEntity, "Restore", "Create",
 		func() (string, *http.Response, error) {
 			r, resp, err := c.BackupsAPI.RestoreBackupV2(ctx, cUUID).Backup(req).Execute()
 			if err != nil {
 				return "", resp, err
 			}
-			taskUUID = *r.TaskUUID
-			return taskUUID, resp, nil
+			d.SetId(r.GetTaskUUID())
+			return r.GetTaskUUID(), resp, nil
 		},
 	); diags != nil {
 		return diags
 	}
-
-	// Set ID using task UUID since restores don't have a persistent ID
-	d.SetId(taskUUID)
 	return resourceRestoreRead(ctx, d, meta)
 }
 
@@ -455,12 +456,17 @@ func resourceRestoreRead(
 	var diags diag.Diagnostics
 
 	// Restores are one-time operations and don't have persistent state to read.
-	// We just verify the resource exists in local state.
+	// We just verify the resource exists in local state, and wait for the
+	// restore if an interrupted apply left it running.
 	if d.Id() == "" {
 		return diag.Errorf("Restore resource has no ID")
 	}
 
-	return diags
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+	return append(diags, utils.ResumePendingTask(ctx, d, cUUID, c,
+		d.Timeout(schema.TimeoutCreate), abortOnCancel)...)
 }
 
 func resourceRestoreDelete(
@@ -170,14 +170,14 @@ func resourceCustomerRead(
 
 	// Use the stored API token if available, otherwise use provider's API key
 	// (yba-cli: authAPI.GetSessionInfo())
-	vc := meta.(*api.APIClient).VanillaClient
-	apiKey := meta.(*api.APIClient).APIKey
+	apiClient := meta.(*api.APIClient)
+	apiKey := apiClient.APIKey
 	storedToken := d.Get("api_token").(string)
 	if storedToken != "" {
 		apiKey = storedToken
 	}
 
-	newAPI, err := api.NewAPIClient(vc.EnableHTTPS, vc.Host, apiKey)
+	newAPI, err := apiClient.WithAPIKey(apiKey)
 	if err != nil {
 		return diag.FromErr(err)
 	}
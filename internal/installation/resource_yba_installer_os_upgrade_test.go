@@ -197,7 +197,7 @@ func checkProviderStillOnYBA(t *testing.T, providerUUID *string) resource.TestCh
 			return errors.New("could not read YBA host/api_token from state")
 		}
 
-		c, err := api.NewAPIClient(true, host, token)
+		c, err := api.NewAPIClient(acctest.TestClientConfig(host, token))
 		if err != nil {
 			return fmt.Errorf("connecting to rehydrated YBA at %s: %w", host, err)
 		}
@@ -0,0 +1,285 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package kmsconfig provides per-provider encryption-at-rest KMS configuration
+// resources (yba_aws_kms_config, yba_gcp_kms_config, yba_azure_kms_config,
+// yba_hashicorp_vault_kms_config) and the yba_kms_configs data source.
+package kmsconfig
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/api"
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+// KMS provider names used in the YBA encryption-at-rest API.
+const (
+	awsKMSProvider       = "AWS"
+	gcpKMSProvider       = "GCP"
+	azureKMSProvider     = "AZU"
+	hashicorpKMSProvider = "HASHICORP"
+)
+
+// kmsConfigTimeouts are shared by the KMS config resources. Creating a config
+// runs a YBA task that validates the credentials against the KMS and creates
+// the master key when it does not exist yet.
+func kmsConfigTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(5 * time.Minute),
+		Update: schema.DefaultTimeout(5 * time.Minute),
+		Delete: schema.DefaultTimeout(5 * time.Minute),
+	}
+}
+
+// kmsConfig is one entry of EncryptionAtRestAPI.ListKMSConfigs. Secrets in
+// credentials are masked by YBA.
+type kmsConfig struct {
+	uuid        string
+	name        string
+	provider    string
+	inUse       bool
+	credentials map[string]interface{}
+}
+
+func parseKMSConfig(raw map[string]interface{}) kmsConfig {
+	metadata, _ := raw["metadata"].(map[string]interface{})
+	credentials, _ := raw["credentials"].(map[string]interface{})
+	config := kmsConfig{credentials: credentials}
+	config.uuid, _ = metadata["configUUID"].(string)
+	config.name, _ = metadata["name"].(string)
+	config.provider, _ = metadata["provider"].(string)
+	config.inUse, _ = metadata["in_use"].(bool)
+	return config
+}
+
+func listKMSConfigs(ctx context.Context, c *client.APIClient, cUUID string) (
+	[]kmsConfig, *http.Response, error) {
+	r, response, err := c.EncryptionAtRestAPI.ListKMSConfigs(ctx, cUUID).Execute()
+	if err != nil {
+		return nil, response, err
+	}
+	configs := make([]kmsConfig, 0, len(r))
+	for _, raw := range r {
+		configs = append(configs, parseKMSConfig(raw))
+	}
+	return configs, response, nil
+}
+
+// findKMSConfig finds a KMS config by UUID and provider
+func findKMSConfig(configs []kmsConfig, uuid string, provider string) (*kmsConfig, error) {
+	for _, c := range configs {
+		if c.uuid == uuid {
+			if c.provider != provider {
+				return nil, fmt.Errorf("KMS config %s is not of provider %s", uuid, provider)
+			}
+			return &c, nil
+		}
+	}
+	return nil, utils.ResourceNotFoundError("KMS config", uuid)
+}
+
+// readKMSConfig fetches the config in d for the Read of a typed resource. It
+// returns nil, and clears the ID, when the config was deleted outside of
+// Terraform.
+func readKMSConfig(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{},
+	provider, label string,
+) (*kmsConfig, diag.Diagnostics) {
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+
+	configs, response, err := listKMSConfigs(ctx, c, cUUID)
+	if err != nil {
+		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			label, "Read")
+		return nil, diag.FromErr(errMessage)
+	}
+	config, err := findKMSConfig(configs, d.Id(), provider)
+	if err != nil {
+		if utils.IsResourceNotFoundError(err) {
+			tflog.Warn(ctx,
+				fmt.Sprintf("%s %s not found, removing from state: %v", label, d.Id(), err))
+			d.SetId("")
+			return nil, nil
+		}
+		return nil, diag.FromErr(err)
+	}
+	if err = d.Set("name", config.name); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if err = d.Set("config_uuid", config.uuid); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if err = d.Set("in_use", config.inUse); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return config, nil
+}
+
+// isMaskedValue reports whether YBA masked a credential value on read: it
+// keeps the first and last characters and replaces the rest with '*'.
+func isMaskedValue(v interface{}) bool {
+	s, ok := v.(string)
+	return ok && (s == "REDACTED" || strings.Contains(s, "**"))
+}
+
+// setCredentialFields copies the non-secret credential keys YBA returns into
+// the attributes they map to. A value YBA masked keeps the configured one.
+func setCredentialFields(
+	d *schema.ResourceData,
+	credentials map[string]interface{},
+	fields map[string]string,
+) error {
+	for attr, key := range fields {
+		v, ok := credentials[key]
+		if !ok || isMaskedValue(v) {
+			continue
+		}
+		if err := d.Set(attr, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createKMSConfig creates a config from data and waits for the YBA task that
+// validates it.
+func createKMSConfig(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{},
+	provider, label string,
+	data map[string]interface{},
+) diag.Diagnostics {
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+
+	data["name"] = d.Get("name").(string)
+	return utils.DispatchAndWait(ctx, "Create "+label, cUUID, c,
+		d.Timeout(schema.TimeoutCreate), abortOnCancel,
+		utils.ResourceEntity, label, "Create",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.EncryptionAtRestAPI.CreateKMSConfig(ctx, cUUID, provider).
+				KMSConfig(data).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			d.SetId(r.GetResourceUUID())
+			return r.GetTaskUUID(), resp, nil
+		},
+	)
+}
+
+// editKMSConfig sends data as the new credentials of the config in d. On
+// failure the secret attributes are put back to their prior values, so state
+// does not record credentials YBA never accepted.
+func editKMSConfig(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{},
+	label string,
+	data map[string]interface{},
+	secrets ...string,
+) diag.Diagnostics {
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+
+	data["name"] = d.Get("name").(string)
+	diags := utils.DispatchAndWait(ctx, "Update "+label, cUUID, c,
+		d.Timeout(schema.TimeoutUpdate), abortOnCancel,
+		utils.ResourceEntity, label, "Update",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.EncryptionAtRestAPI.EditKMSConfig(ctx, cUUID, d.Id()).
+				KMSConfig(data).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			return r.GetTaskUUID(), resp, nil
+		},
+	)
+	if diags.HasError() {
+		utils.RevertFields(d, secrets...)
+	}
+	return diags
+}
+
+// resourceKMSConfigDelete is the common delete function for all KMS configs.
+// YBA refuses to delete a config that still encrypts a universe or a backup.
+func resourceKMSConfigDelete(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+
+	diags := utils.DispatchAndWait(ctx, "Delete KMS Config", cUUID, c,
+		d.Timeout(schema.TimeoutDelete), abortOnCancel,
+		utils.ResourceEntity, "KMS Config", "Delete",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.EncryptionAtRestAPI.DeleteKMSConfig(ctx, cUUID, d.Id()).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			return r.GetTaskUUID(), resp, nil
+		},
+	)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// commonKMSConfigSchema holds the attributes every KMS config resource has.
+func commonKMSConfigSchema(label string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+			Description: fmt.Sprintf("Name of the %s configuration. Changing this value "+
+				"forces resource recreation.", label),
+		},
+		"config_uuid": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "UUID of the KMS configuration.",
+		},
+		"in_use": {
+			Type:     schema.TypeBool,
+			Computed: true,
+			Description: "Whether a universe or backup is encrypted with this KMS " +
+				"configuration. YugabyteDB Anywhere refuses to delete a configuration " +
+				"that is in use.",
+		},
+	}
+}
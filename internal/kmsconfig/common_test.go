@@ -0,0 +1,79 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kmsconfig
+
+import (
+	"testing"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+func TestParseAndFindKMSConfig(t *testing.T) {
+	configs := []kmsConfig{
+		parseKMSConfig(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"configUUID": "aws-1",
+				"name":       "aws-kms",
+				"provider":   "AWS",
+				"in_use":     true,
+			},
+			"credentials": map[string]interface{}{"AWS_REGION": "us-west-2"},
+		}),
+		parseKMSConfig(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"configUUID": "gcp-1",
+				"name":       "gcp-kms",
+				"provider":   "GCP",
+			},
+		}),
+	}
+
+	config, err := findKMSConfig(configs, "aws-1", awsKMSProvider)
+	if err != nil {
+		t.Fatalf("findKMSConfig(aws-1): %v", err)
+	}
+	if config.name != "aws-kms" || !config.inUse ||
+		config.credentials["AWS_REGION"] != "us-west-2" {
+		t.Errorf("findKMSConfig(aws-1) = %+v", *config)
+	}
+
+	if _, err = findKMSConfig(configs, "gcp-1", awsKMSProvider); err == nil ||
+		utils.IsResourceNotFoundError(err) {
+		t.Errorf("findKMSConfig(gcp-1, AWS) error = %v, want provider mismatch", err)
+	}
+	_, err = findKMSConfig(configs, "missing", awsKMSProvider)
+	if !utils.IsResourceNotFoundError(err) {
+		t.Errorf("findKMSConfig(missing) error = %v, want not found", err)
+	}
+}
+
+func TestIsMaskedValue(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  bool
+	}{
+		{"REDACTED", true},
+		{"A******Z", true},
+		{"AKIAEXAMPLE", false},
+		{"", false},
+		{2048, false},
+	}
+	for _, tt := range tests {
+		if got := isMaskedValue(tt.value); got != tt.want {
+			t.Errorf("isMaskedValue(%v) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
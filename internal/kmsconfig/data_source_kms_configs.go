@@ -0,0 +1,146 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kmsconfig
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/api"
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+// KMSConfigs lists the customer's encryption-at-rest KMS configs
+func KMSConfigs() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieve list of encryption-at-rest KMS configurations.",
+
+		ReadContext: dataSourceKMSConfigsRead,
+
+		Schema: map[string]*schema.Schema{
+			"config_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Accepts name of the KMS configuration. The corresponding " +
+					"KMS config UUID is stored in ID to be used as kms_config_uuid in the " +
+					"*yba_backup*, *yba_backup_schedule* and *yba_restore* resources.",
+			},
+			"kms_provider": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					awsKMSProvider, gcpKMSProvider, azureKMSProvider, hashicorpKMSProvider,
+				}, false),
+				Description: "List only configurations of this KMS provider. " +
+					"Allowed values: AWS, GCP, AZU, HASHICORP.",
+			},
+			"uuid_list": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "List of KMS configuration UUIDs.",
+			},
+			"kms_configs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "KMS configurations, without their credentials.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uuid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "UUID of the KMS configuration.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the KMS configuration.",
+						},
+						"kms_provider": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "KMS provider: AWS, GCP, AZU or HASHICORP.",
+						},
+						"in_use": {
+							Type:     schema.TypeBool,
+							Computed: true,
+							Description: "Whether a universe or backup is encrypted with " +
+								"this KMS configuration.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKMSConfigsRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+
+	configs, response, err := listKMSConfigs(ctx, c, cUUID)
+	if err != nil {
+		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.DataSourceEntity,
+			"KMS Configs", "Read")
+		return diag.FromErr(errMessage)
+	}
+
+	configName := d.Get("config_name").(string)
+	provider := d.Get("kms_provider").(string)
+	ids := []string{}
+	list := []map[string]interface{}{}
+	var matched string
+	for _, config := range configs {
+		if provider != "" && config.provider != provider {
+			continue
+		}
+		ids = append(ids, config.uuid)
+		list = append(list, map[string]interface{}{
+			"uuid":         config.uuid,
+			"name":         config.name,
+			"kms_provider": config.provider,
+			"in_use":       config.inUse,
+		})
+		if configName != "" && config.name == configName {
+			matched = config.uuid
+		}
+	}
+	if err = d.Set("uuid_list", ids); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("kms_configs", list); err != nil {
+		return diag.FromErr(err)
+	}
+	switch {
+	case configName != "" && matched == "":
+		return diag.Errorf("No KMS config named %q found", configName)
+	case configName != "":
+		d.SetId(matched)
+	case len(ids) != 0:
+		d.SetId(ids[0])
+	default:
+		d.SetId("")
+	}
+	return diags
+}
@@ -0,0 +1,191 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kmsconfig
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+// awsKMSFields maps the non-secret attributes of yba_aws_kms_config to the
+// keys YBA stores them under.
+var awsKMSFields = map[string]string{
+	"region":       "AWS_REGION",
+	"cmk_id":       "cmk_id",
+	"kms_endpoint": "AWS_KMS_ENDPOINT",
+}
+
+// ResourceAWSKMSConfig defines AWS KMS encryption-at-rest configuration resource
+func ResourceAWSKMSConfig() *schema.Resource {
+	s := commonKMSConfigSchema("AWS KMS")
+	s["region"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "AWS region of the KMS (e.g., us-west-2).",
+	}
+	s["access_key_id"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		Sensitive:     true,
+		ConflictsWith: []string{"use_iam_instance_profile"},
+		RequiredWith:  []string{"secret_access_key"},
+		Description: "AWS Access Key ID. Required with secret_access_key " +
+			"when use_iam_instance_profile is false. " +
+			"Stored in Terraform state - use an encrypted backend for security.",
+	}
+	s["secret_access_key"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		Sensitive:     true,
+		ConflictsWith: []string{"use_iam_instance_profile"},
+		RequiredWith:  []string{"access_key_id"},
+		Description: "AWS Secret Access Key. Required with access_key_id " +
+			"when use_iam_instance_profile is false. " +
+			"Stored in Terraform state - use an encrypted backend for security.",
+	}
+	s["use_iam_instance_profile"] = &schema.Schema{
+		Type:          schema.TypeBool,
+		Optional:      true,
+		Default:       false,
+		ForceNew:      true,
+		ConflictsWith: []string{"access_key_id", "secret_access_key"},
+		Description: "Use IAM Role from the YugabyteDB Anywhere host. " +
+			"If true, access_key_id and secret_access_key are not required. Default: false.",
+	}
+	s["cmk_id"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		ForceNew:      true,
+		ConflictsWith: []string{"cmk_policy"},
+		Description: "ID or ARN of an existing customer master key. When neither " +
+			"cmk_id nor cmk_policy is set, YugabyteDB Anywhere creates a new key.",
+	}
+	s["cmk_policy"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		ForceNew:         true,
+		ConflictsWith:    []string{"cmk_id"},
+		ValidateFunc:     validation.StringIsJSON,
+		DiffSuppressFunc: structure.SuppressJsonDiff,
+		Description: "Key policy JSON for the customer master key YugabyteDB Anywhere " +
+			"creates.",
+	}
+	s["kms_endpoint"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "Custom AWS KMS endpoint, e.g. a VPC endpoint.",
+	}
+
+	return &schema.Resource{
+		Description: "AWS KMS Configuration for YugabyteDB Anywhere encryption at rest.",
+
+		CreateContext: resourceAWSKMSConfigCreate,
+		ReadContext:   resourceAWSKMSConfigRead,
+		UpdateContext: resourceAWSKMSConfigUpdate,
+		DeleteContext: resourceKMSConfigDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: kmsConfigTimeouts(),
+
+		Schema: s,
+	}
+}
+
+func buildAWSKMSData(d *schema.ResourceData) map[string]interface{} {
+	data := map[string]interface{}{
+		"AWS_REGION": d.Get("region").(string),
+	}
+	if !d.Get("use_iam_instance_profile").(bool) {
+		if v := d.Get("access_key_id").(string); v != "" {
+			data[utils.AWSAccessKeyEnv] = v
+		}
+		if v := d.Get("secret_access_key").(string); v != "" {
+			data[utils.AWSSecretAccessKeyEnv] = v
+		}
+	}
+	if v := d.Get("cmk_id").(string); v != "" {
+		data["cmk_id"] = v
+	}
+	if v := d.Get("cmk_policy").(string); v != "" {
+		data["cmk_policy"] = v
+	}
+	if v := d.Get("kms_endpoint").(string); v != "" {
+		data["AWS_KMS_ENDPOINT"] = v
+	}
+	return data
+}
+
+func resourceAWSKMSConfigCreate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	if diags := createKMSConfig(ctx, d, meta, awsKMSProvider, "AWS KMS Config",
+		buildAWSKMSData(d)); diags != nil {
+		return diags
+	}
+	return resourceAWSKMSConfigRead(ctx, d, meta)
+}
+
+func resourceAWSKMSConfigRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	config, diags := readKMSConfig(ctx, d, meta, awsKMSProvider, "AWS KMS Config")
+	if config == nil {
+		return diags
+	}
+	// Don't read back access keys - they're sensitive and masked in the API response
+	if err := setCredentialFields(d, config.credentials, awsKMSFields); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceAWSKMSConfigUpdate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) (diags diag.Diagnostics) {
+
+	// Always refresh state before returning. On success, Read errors are
+	// propagated. On failure, they are swallowed so the original error is preserved.
+	defer func() {
+		readDiags := resourceAWSKMSConfigRead(ctx, d, meta)
+		if !diags.HasError() {
+			diags = append(diags, readDiags...)
+		}
+	}()
+
+	// Only the credentials can change; every other attribute forces a new config.
+	data := map[string]interface{}{
+		"AWS_REGION":                d.Get("region").(string),
+		utils.AWSAccessKeyEnv:       d.Get("access_key_id").(string),
+		utils.AWSSecretAccessKeyEnv: d.Get("secret_access_key").(string),
+	}
+	return editKMSConfig(ctx, d, meta, "AWS KMS Config", data,
+		"access_key_id", "secret_access_key")
+}
@@ -0,0 +1,168 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kmsconfig
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// azureKMSFields maps the non-secret attributes of yba_azure_kms_config to the
+// keys YBA stores them under.
+var azureKMSFields = map[string]string{
+	"client_id":     "AZU_CLIENT_ID",
+	"tenant_id":     "AZU_TENANT_ID",
+	"vault_url":     "AZU_VAULT_URL",
+	"key_name":      "AZU_KEY_NAME",
+	"key_algorithm": "AZU_KEY_ALGORITHM",
+	"key_size":      "AZU_KEY_SIZE",
+}
+
+// ResourceAzureKMSConfig defines Azure Key Vault encryption-at-rest configuration resource
+func ResourceAzureKMSConfig() *schema.Resource {
+	s := commonKMSConfigSchema("Azure KMS")
+	s["client_id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Client ID of the Azure application or managed identity.",
+	}
+	s["client_secret"] = &schema.Schema{
+		Type:      schema.TypeString,
+		Optional:  true,
+		Sensitive: true,
+		Description: "Client secret of the Azure application. Leave empty to " +
+			"authenticate with the managed identity of the YugabyteDB Anywhere host. " +
+			"Stored in Terraform state - use an encrypted backend for security.",
+	}
+	s["tenant_id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Azure tenant ID.",
+	}
+	s["vault_url"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "URL of the key vault (e.g., https://<vault>.vault.azure.net/).",
+	}
+	s["key_name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+		Description: "Name of the master key. YugabyteDB Anywhere creates it when it " +
+			"does not exist.",
+	}
+	s["key_algorithm"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "RSA",
+		ForceNew:     true,
+		ValidateFunc: validation.StringInSlice([]string{"RSA"}, false),
+		Description:  "Algorithm of a master key YugabyteDB Anywhere creates. Default: RSA.",
+	}
+	s["key_size"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      2048,
+		ForceNew:     true,
+		ValidateFunc: validation.IntInSlice([]int{2048, 3072, 4096}),
+		Description: "Size in bits of a master key YugabyteDB Anywhere creates. " +
+			"Allowed values: 2048, 3072, 4096. Default: 2048.",
+	}
+
+	return &schema.Resource{
+		Description: "Azure Key Vault Configuration for YugabyteDB Anywhere encryption at rest.",
+
+		CreateContext: resourceAzureKMSConfigCreate,
+		ReadContext:   resourceAzureKMSConfigRead,
+		UpdateContext: resourceAzureKMSConfigUpdate,
+		DeleteContext: resourceKMSConfigDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: kmsConfigTimeouts(),
+
+		Schema: s,
+	}
+}
+
+func buildAzureKMSData(d *schema.ResourceData) map[string]interface{} {
+	data := map[string]interface{}{
+		"AZU_CLIENT_ID":     d.Get("client_id").(string),
+		"AZU_TENANT_ID":     d.Get("tenant_id").(string),
+		"AZU_VAULT_URL":     d.Get("vault_url").(string),
+		"AZU_KEY_NAME":      d.Get("key_name").(string),
+		"AZU_KEY_ALGORITHM": d.Get("key_algorithm").(string),
+		"AZU_KEY_SIZE":      d.Get("key_size").(int),
+	}
+	if v := d.Get("client_secret").(string); v != "" {
+		data["AZU_CLIENT_SECRET"] = v
+	}
+	return data
+}
+
+func resourceAzureKMSConfigCreate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	if diags := createKMSConfig(ctx, d, meta, azureKMSProvider, "Azure KMS Config",
+		buildAzureKMSData(d)); diags != nil {
+		return diags
+	}
+	return resourceAzureKMSConfigRead(ctx, d, meta)
+}
+
+func resourceAzureKMSConfigRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	config, diags := readKMSConfig(ctx, d, meta, azureKMSProvider, "Azure KMS Config")
+	if config == nil {
+		return diags
+	}
+	// YBA returns the key size as a JSON number
+	if v, ok := config.credentials["AZU_KEY_SIZE"].(float64); ok {
+		config.credentials["AZU_KEY_SIZE"] = int(v)
+	}
+	// Don't read back client_secret - it's sensitive and masked in the API response
+	if err := setCredentialFields(d, config.credentials, azureKMSFields); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceAzureKMSConfigUpdate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) (diags diag.Diagnostics) {
+
+	// Always refresh state before returning. On success, Read errors are
+	// propagated. On failure, they are swallowed so the original error is preserved.
+	defer func() {
+		readDiags := resourceAzureKMSConfigRead(ctx, d, meta)
+		if !diags.HasError() {
+			diags = append(diags, readDiags...)
+		}
+	}()
+
+	return editKMSConfig(ctx, d, meta, "Azure KMS Config", buildAzureKMSData(d),
+		"client_secret")
+}
@@ -0,0 +1,181 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kmsconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// gcpKMSFields maps the non-secret attributes of yba_gcp_kms_config to the
+// keys YBA stores them under.
+var gcpKMSFields = map[string]string{
+	"location_id":      "LOCATION_ID",
+	"key_ring_id":      "KEY_RING_ID",
+	"crypto_key_id":    "CRYPTO_KEY_ID",
+	"protection_level": "PROTECTION_LEVEL",
+	"kms_endpoint":     "GCP_KMS_ENDPOINT",
+}
+
+// ResourceGCPKMSConfig defines GCP KMS encryption-at-rest configuration resource
+func ResourceGCPKMSConfig() *schema.Resource {
+	s := commonKMSConfigSchema("GCP KMS")
+	s["credentials"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		Sensitive:    true,
+		ValidateFunc: validation.StringIsJSON,
+		Description: "GCP Service Account credentials JSON. The account needs the " +
+			"Cloud KMS Admin and Cloud KMS CryptoKey Encrypter/Decrypter roles. " +
+			"Stored in Terraform state - use an encrypted backend for security.",
+	}
+	s["location_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "global",
+		ForceNew: true,
+		Description: "Location of the key ring (e.g., global, us-central1). " +
+			"Default: global.",
+	}
+	s["key_ring_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+		Description: "Name of the key ring. YugabyteDB Anywhere creates it when it " +
+			"does not exist.",
+	}
+	s["crypto_key_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+		Description: "Name of the crypto key. YugabyteDB Anywhere creates it when it " +
+			"does not exist.",
+	}
+	s["protection_level"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "HSM",
+		ForceNew:     true,
+		ValidateFunc: validation.StringInSlice([]string{"SOFTWARE", "HSM"}, false),
+		Description: "Protection level of a crypto key YugabyteDB Anywhere creates. " +
+			"Allowed values: SOFTWARE, HSM. Default: HSM.",
+	}
+	s["kms_endpoint"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Custom GCP KMS endpoint.",
+	}
+
+	return &schema.Resource{
+		Description: "GCP KMS Configuration for YugabyteDB Anywhere encryption at rest.",
+
+		CreateContext: resourceGCPKMSConfigCreate,
+		ReadContext:   resourceGCPKMSConfigRead,
+		UpdateContext: resourceGCPKMSConfigUpdate,
+		DeleteContext: resourceKMSConfigDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: kmsConfigTimeouts(),
+
+		Schema: s,
+	}
+}
+
+// gcpKMSCredentials parses the credentials JSON; YBA takes it as an object.
+func gcpKMSCredentials(d *schema.ResourceData) (map[string]interface{}, error) {
+	credentials, err := structure.ExpandJsonFromString(d.Get("credentials").(string))
+	if err != nil {
+		return nil, fmt.Errorf("credentials is not valid JSON: %w", err)
+	}
+	return credentials, nil
+}
+
+func buildGCPKMSData(d *schema.ResourceData) (map[string]interface{}, error) {
+	credentials, err := gcpKMSCredentials(d)
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]interface{}{
+		"GCP_CONFIG":       credentials,
+		"LOCATION_ID":      d.Get("location_id").(string),
+		"KEY_RING_ID":      d.Get("key_ring_id").(string),
+		"CRYPTO_KEY_ID":    d.Get("crypto_key_id").(string),
+		"PROTECTION_LEVEL": d.Get("protection_level").(string),
+	}
+	if v := d.Get("kms_endpoint").(string); v != "" {
+		data["GCP_KMS_ENDPOINT"] = v
+	}
+	return data, nil
+}
+
+func resourceGCPKMSConfigCreate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	data, err := buildGCPKMSData(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if diags := createKMSConfig(ctx, d, meta, gcpKMSProvider, "GCP KMS Config",
+		data); diags != nil {
+		return diags
+	}
+	return resourceGCPKMSConfigRead(ctx, d, meta)
+}
+
+func resourceGCPKMSConfigRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	config, diags := readKMSConfig(ctx, d, meta, gcpKMSProvider, "GCP KMS Config")
+	if config == nil {
+		return diags
+	}
+	// Don't read back credentials - they're sensitive and masked in the API response
+	if err := setCredentialFields(d, config.credentials, gcpKMSFields); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceGCPKMSConfigUpdate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) (diags diag.Diagnostics) {
+
+	// Always refresh state before returning. On success, Read errors are
+	// propagated. On failure, they are swallowed so the original error is preserved.
+	defer func() {
+		readDiags := resourceGCPKMSConfigRead(ctx, d, meta)
+		if !diags.HasError() {
+			diags = append(diags, readDiags...)
+		}
+	}()
+
+	data, err := buildGCPKMSData(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return editKMSConfig(ctx, d, meta, "GCP KMS Config", data, "credentials")
+}
@@ -0,0 +1,180 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kmsconfig
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// hashicorpVaultKMSFields maps the non-secret attributes of
+// yba_hashicorp_vault_kms_config to the keys YBA stores them under.
+var hashicorpVaultKMSFields = map[string]string{
+	"vault_address":  "HC_VAULT_ADDRESS",
+	"engine":         "HC_VAULT_ENGINE",
+	"mount_path":     "HC_VAULT_MOUNT_PATH",
+	"key_name":       "HC_VAULT_KEY_NAME",
+	"role_id":        "HC_VAULT_ROLE_ID",
+	"auth_namespace": "HC_VAULT_AUTH_NAMESPACE",
+}
+
+// ResourceHashicorpVaultKMSConfig defines HashiCorp Vault encryption-at-rest
+// configuration resource
+func ResourceHashicorpVaultKMSConfig() *schema.Resource {
+	s := commonKMSConfigSchema("HashiCorp Vault KMS")
+	s["vault_address"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "Vault address (e.g., https://vault.example.com:8200).",
+	}
+	s["token"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		Sensitive:     true,
+		ExactlyOneOf:  []string{"token", "role_id"},
+		ConflictsWith: []string{"role_id", "secret_id"},
+		Description: "Vault token. Exactly one of token or role_id must be set. " +
+			"Stored in Terraform state - use an encrypted backend for security.",
+	}
+	s["role_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		RequiredWith: []string{"secret_id"},
+		Description:  "AppRole role ID, used with secret_id instead of a token.",
+	}
+	s["secret_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Sensitive:    true,
+		RequiredWith: []string{"role_id"},
+		Description: "AppRole secret ID. Required with role_id. " +
+			"Stored in Terraform state - use an encrypted backend for security.",
+	}
+	s["auth_namespace"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		RequiredWith: []string{"role_id"},
+		Description:  "Vault namespace the AppRole is defined in.",
+	}
+	s["engine"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     "transit",
+		ForceNew:    true,
+		Description: "Secret engine. Only transit is supported. Default: transit.",
+	}
+	s["mount_path"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     "transit/",
+		ForceNew:    true,
+		Description: "Mount path of the secret engine. Default: transit/.",
+	}
+	s["key_name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "key_yugabyte",
+		ForceNew: true,
+		Description: "Name of the transit key. YugabyteDB Anywhere creates it when it " +
+			"does not exist. Default: key_yugabyte.",
+	}
+
+	return &schema.Resource{
+		Description: "HashiCorp Vault KMS Configuration for YugabyteDB Anywhere " +
+			"encryption at rest.",
+
+		CreateContext: resourceHashicorpVaultKMSConfigCreate,
+		ReadContext:   resourceHashicorpVaultKMSConfigRead,
+		UpdateContext: resourceHashicorpVaultKMSConfigUpdate,
+		DeleteContext: resourceKMSConfigDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: kmsConfigTimeouts(),
+
+		Schema: s,
+	}
+}
+
+func buildHashicorpVaultKMSData(d *schema.ResourceData) map[string]interface{} {
+	data := map[string]interface{}{
+		"HC_VAULT_ADDRESS":    d.Get("vault_address").(string),
+		"HC_VAULT_ENGINE":     d.Get("engine").(string),
+		"HC_VAULT_MOUNT_PATH": d.Get("mount_path").(string),
+		"HC_VAULT_KEY_NAME":   d.Get("key_name").(string),
+	}
+	if v := d.Get("token").(string); v != "" {
+		data["HC_VAULT_TOKEN"] = v
+	}
+	if v := d.Get("role_id").(string); v != "" {
+		data["HC_VAULT_ROLE_ID"] = v
+		data["HC_VAULT_SECRET_ID"] = d.Get("secret_id").(string)
+		if ns := d.Get("auth_namespace").(string); ns != "" {
+			data["HC_VAULT_AUTH_NAMESPACE"] = ns
+		}
+	}
+	return data
+}
+
+func resourceHashicorpVaultKMSConfigCreate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	if diags := createKMSConfig(ctx, d, meta, hashicorpKMSProvider,
+		"HashiCorp Vault KMS Config", buildHashicorpVaultKMSData(d)); diags != nil {
+		return diags
+	}
+	return resourceHashicorpVaultKMSConfigRead(ctx, d, meta)
+}
+
+func resourceHashicorpVaultKMSConfigRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	config, diags := readKMSConfig(ctx, d, meta, hashicorpKMSProvider,
+		"HashiCorp Vault KMS Config")
+	if config == nil {
+		return diags
+	}
+	// Don't read back token or secret_id - they're sensitive and masked in the API response
+	if err := setCredentialFields(d, config.credentials, hashicorpVaultKMSFields); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceHashicorpVaultKMSConfigUpdate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) (diags diag.Diagnostics) {
+
+	// Always refresh state before returning. On success, Read errors are
+	// propagated. On failure, they are swallowed so the original error is preserved.
+	defer func() {
+		readDiags := resourceHashicorpVaultKMSConfigRead(ctx, d, meta)
+		if !diags.HasError() {
+			diags = append(diags, readDiags...)
+		}
+	}()
+
+	return editKMSConfig(ctx, d, meta, "HashiCorp Vault KMS Config",
+		buildHashicorpVaultKMSData(d), "token", "secret_id")
+}
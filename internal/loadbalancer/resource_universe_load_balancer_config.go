@@ -118,6 +118,7 @@ func ResourceUniverseLoadBalancerConfig() *schema.Resource {
 					},
 				},
 			},
+			utils.PendingTaskUUIDKey: utils.PendingTaskUUIDSchema(),
 		},
 	}
 }
@@ -151,9 +152,16 @@ func resourceUniverseLoadBalancerConfigCreate(
 		return diag.FromErr(err)
 	}
 
-	if diags := dispatchLoadBalancerConfig(ctx, apiClient, uniUUID, clusters,
-		uni.UniverseDetails.GetNodeDetailsSet(),
-		d.Timeout(schema.TimeoutCreate), "Create"); diags != nil {
+	diags := dispatchLoadBalancerConfig(utils.TrackPendingTask(ctx, d), apiClient, uniUUID,
+		clusters, uni.UniverseDetails.GetNodeDetailsSet(),
+		d.Timeout(schema.TimeoutCreate), "Create")
+	if utils.PendingTaskUUID(d) != "" {
+		// Interrupted: keep the config in state so the next run waits for
+		// the task instead of dispatching it again.
+		d.SetId(uniUUID)
+		return diags
+	}
+	if diags != nil {
 		return diags
 	}
 
@@ -202,7 +210,7 @@ func dispatchLoadBalancerConfig(
 		UniverseUUID: uniUUID, Clusters: clusters, NodeDetailsSet: nodes,
 	}
 	return utils.DispatchAndWait(ctx, "Universe Load Balancer Config "+operation,
-		apiClient.CustomerID, apiClient.YugawareClient, timeout,
+		apiClient.CustomerID, apiClient.YugawareClient, timeout, apiClient.AbortTasksOnCancel,
 		utils.ResourceEntity, "Universe Load Balancer Config", operation,
 		func() (string, *http.Response, error) {
 			return apiClient.VanillaClient.UpdateLoadBalancerConfig(
@@ -283,13 +291,19 @@ func resourceUniverseLoadBalancerConfigRead(
 ) diag.Diagnostics {
 	apiClient := meta.(*api.APIClient)
 
+	diags := utils.ResumePendingTask(ctx, d, apiClient.CustomerID, apiClient.YugawareClient,
+		d.Timeout(schema.TimeoutUpdate), apiClient.AbortTasksOnCancel)
+	if diags.HasError() {
+		return diags
+	}
+
 	uni, err := getUniverse(ctx, apiClient, d.Id(), "Read")
 	if err != nil {
 		if errors.Is(err, utils.ErrUniverseMissing) {
 			d.SetId("")
-			return nil
+			return diags
 		}
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 
 	// The resource ID is the universe UUID; re-derive the field so imports work.
@@ -300,7 +314,7 @@ func resourceUniverseLoadBalancerConfigRead(
 		flattenLoadBalancerConfig(uni.UniverseDetails.Clusters)); err != nil {
 		return diag.FromErr(err)
 	}
-	return nil
+	return diags
 }
 
 // flattenLoadBalancerConfig inverts applyLoadBalancerConfig; the unconditional
@@ -371,20 +385,26 @@ func resourceUniverseLoadBalancerConfigUpdate(
 	apiClient := meta.(*api.APIClient)
 	uniUUID := d.Id()
 
+	diags := utils.ResumePendingTask(ctx, d, apiClient.CustomerID, apiClient.YugawareClient,
+		d.Timeout(schema.TimeoutUpdate), apiClient.AbortTasksOnCancel)
+	if diags.HasError() {
+		return diags
+	}
+
 	uni, err := getUniverse(ctx, apiClient, uniUUID, "Update - Fetch universe")
 	if err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 
 	clusters, err := buildDesiredClusters(
 		uni.UniverseDetails.Clusters, d.Get("load_balancer").(*schema.Set).List())
 	if err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 
-	return dispatchLoadBalancerConfig(ctx, apiClient, uniUUID, clusters,
-		uni.UniverseDetails.GetNodeDetailsSet(),
-		d.Timeout(schema.TimeoutUpdate), "Update")
+	return append(diags, dispatchLoadBalancerConfig(utils.TrackPendingTask(ctx, d),
+		apiClient, uniUUID, clusters, uni.UniverseDetails.GetNodeDetailsSet(),
+		d.Timeout(schema.TimeoutUpdate), "Update")...)
 }
 
 // Delete disables LB management (enableLB=false, lbName/lbFQDN cleared) so YBA
@@ -0,0 +1,250 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/api"
+)
+
+// insecureSkipVerifyEnv is the environment fallback for insecure_skip_verify.
+// The attribute has no DefaultFunc: an unset value must stay distinguishable
+// from an explicit false, and a DefaultFunc would hide that.
+const insecureSkipVerifyEnv = "YBA_INSECURE_SKIP_VERIFY"
+
+// insecureSkipVerifySetting returns insecure_skip_verify from the provider
+// block, falling back to YBA_INSECURE_SKIP_VERIFY. explicit is false when
+// neither is set.
+func insecureSkipVerifySetting(d *schema.ResourceData) (value bool, explicit bool) {
+	v, diags := d.GetRawConfigAt(cty.GetAttrPath("insecure_skip_verify"))
+	if !diags.HasError() && !v.IsNull() && v.IsKnown() && v.Type() == cty.Bool {
+		return v.True(), true
+	}
+	if env, ok := os.LookupEnv(insecureSkipVerifyEnv); ok && env != "" {
+		if b, err := strconv.ParseBool(env); err == nil {
+			return b, true
+		}
+	}
+	return false, false
+}
+
+// buildTLSConfig reads the TLS attributes of the provider block.
+//
+// An unset insecure_skip_verify keeps the v1.x behaviour of skipping
+// verification, unless a CA bundle is configured: supplying a CA is an
+// unambiguous request to verify against it.
+func buildTLSConfig(d *schema.ResourceData) (api.TLSConfig, diag.Diagnostics) {
+	cfg := api.TLSConfig{
+		CACertFile: d.Get("ca_cert_file").(string),
+		CACertPEM:  d.Get("ca_cert_pem").(string),
+		ServerName: d.Get("tls_server_name").(string),
+
+		ClientCertFile: d.Get("client_cert_file").(string),
+		ClientKeyFile:  d.Get("client_key_file").(string),
+		ClientCertPEM:  d.Get("client_cert_pem").(string),
+		ClientKeyPEM:   d.Get("client_key_pem").(string),
+	}
+	hasCert := cfg.ClientCertFile != "" || cfg.ClientCertPEM != ""
+	hasKey := cfg.ClientKeyFile != "" || cfg.ClientKeyPEM != ""
+	if hasCert != hasKey {
+		return cfg, diag.Errorf("client_cert_file/client_cert_pem and " +
+			"client_key_file/client_key_pem must be set together for mutual TLS")
+	}
+	if env, ok := os.LookupEnv(insecureSkipVerifyEnv); ok && env != "" {
+		if _, err := strconv.ParseBool(env); err != nil {
+			return cfg, diag.Errorf("%s must be a boolean, got %q", insecureSkipVerifyEnv, env)
+		}
+	}
+	skip, explicit := insecureSkipVerifySetting(d)
+	if explicit {
+		cfg.InsecureSkipVerify = skip
+	} else {
+		cfg.InsecureSkipVerify = !cfg.HasCA()
+	}
+
+	var diags diag.Diagnostics
+	if cfg.InsecureSkipVerify && cfg.HasCA() {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "CA bundle ignored",
+			Detail: fmt.Sprintf("insecure_skip_verify is true, so the configured %s is "+
+				"not used to verify the YugabyteDB Anywhere server certificate.",
+				caSource(cfg)),
+		})
+	}
+	return cfg, diags
+}
+
+func caSource(cfg api.TLSConfig) string {
+	if cfg.CACertFile != "" {
+		return "ca_cert_file"
+	}
+	return "ca_cert_pem"
+}
+
+// buildProxyConfig reads the proxy attributes and custom_headers of the
+// provider block. Proxy-* headers are also sent on CONNECT so proxies that
+// authenticate by header work for HTTPS, where the request headers
+// themselves are inside the tunnel.
+func buildProxyConfig(d *schema.ResourceData) (api.ProxyConfig, map[string]string) {
+	headers := make(map[string]string)
+	for k, v := range d.Get("custom_headers").(map[string]interface{}) {
+		headers[k] = v.(string)
+	}
+	cfg := api.ProxyConfig{
+		URL:     d.Get("proxy_url").(string),
+		NoProxy: d.Get("no_proxy").(string),
+	}
+	for k, v := range headers {
+		if strings.HasPrefix(strings.ToLower(k), "proxy-") {
+			if cfg.ConnectHeaders == nil {
+				cfg.ConnectHeaders = make(map[string]string)
+			}
+			cfg.ConnectHeaders[k] = v
+		}
+	}
+	return cfg, headers
+}
+
+// logConnectionSettings logs the proxy and custom headers at debug level and
+// returns ctx with every sensitive header value masked, so the values never
+// reach tflog output even if a later message includes them.
+func logConnectionSettings(
+	ctx context.Context, proxy api.ProxyConfig, headers map[string]string,
+) context.Context {
+	for k, v := range headers {
+		if api.IsSensitiveHeader(k) && v != "" {
+			ctx = tflog.MaskLogStrings(ctx, v)
+		}
+	}
+	fields := map[string]interface{}{
+		"custom_headers": api.RedactHeaders(headers),
+	}
+	if proxy.URL != "" {
+		fields["proxy_url"] = api.RedactURL(proxy.URL)
+	}
+	if proxy.NoProxy != "" {
+		fields["no_proxy"] = proxy.NoProxy
+	}
+	tflog.Debug(ctx, "YugabyteDB Anywhere connection settings", fields)
+	return ctx
+}
+
+// buildRetryConfig reads max_retries, retry_wait_min and retry_wait_max.
+func buildRetryConfig(d *schema.ResourceData) (api.RetryConfig, error) {
+	cfg := api.RetryConfig{MaxRetries: d.Get("max_retries").(int)}
+	if cfg.MaxRetries < 0 {
+		return cfg, fmt.Errorf("max_retries must not be negative, got %d", cfg.MaxRetries)
+	}
+	var err error
+	if cfg.WaitMin, err = parseRetryWait(d, "retry_wait_min"); err != nil {
+		return cfg, err
+	}
+	if cfg.WaitMax, err = parseRetryWait(d, "retry_wait_max"); err != nil {
+		return cfg, err
+	}
+	if cfg.WaitMax < cfg.WaitMin {
+		return cfg, fmt.Errorf("retry_wait_max (%s) must not be less than "+
+			"retry_wait_min (%s)", cfg.WaitMax, cfg.WaitMin)
+	}
+	return cfg, nil
+}
+
+func parseRetryWait(d *schema.ResourceData, key string) (time.Duration, error) {
+	s := d.Get(key).(string)
+	wait, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a duration such as 1s or 500ms, got %q", key, s)
+	}
+	if wait <= 0 {
+		return 0, fmt.Errorf("%s must be positive, got %q", key, s)
+	}
+	return wait, nil
+}
+
+// buildRateLimitConfig reads max_requests_per_second and
+// max_concurrent_requests.
+func buildRateLimitConfig(d *schema.ResourceData) (api.RateLimitConfig, error) {
+	cfg := api.RateLimitConfig{
+		RequestsPerSecond: d.Get("max_requests_per_second").(float64),
+		MaxConcurrent:     d.Get("max_concurrent_requests").(int),
+	}
+	if cfg.RequestsPerSecond < 0 {
+		return cfg, fmt.Errorf("max_requests_per_second must not be negative, got %g",
+			cfg.RequestsPerSecond)
+	}
+	if cfg.MaxConcurrent < 0 {
+		return cfg, fmt.Errorf("max_concurrent_requests must not be negative, got %d",
+			cfg.MaxConcurrent)
+	}
+	return cfg, nil
+}
+
+// buildCacheTTL reads cache_ttl. Zero disables the response cache.
+func buildCacheTTL(d *schema.ResourceData) (time.Duration, error) {
+	s := d.Get("cache_ttl").(string)
+	ttl, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("cache_ttl must be a duration such as 30s, or 0, got %q", s)
+	}
+	if ttl < 0 {
+		return 0, fmt.Errorf("cache_ttl must not be negative, got %q", s)
+	}
+	return ttl, nil
+}
+
+// credentials returns the login settings of the provider block and checks
+// that at most one authentication mode is configured. api_token is validated
+// here rather than with ConflictsWith because its environment default would
+// otherwise conflict with an email set in the configuration.
+func credentials(d *schema.ResourceData) (
+	email, password, bearerToken string, diags diag.Diagnostics) {
+	email = d.Get("email").(string)
+	password = d.Get("password").(string)
+	bearerToken = d.Get("bearer_token").(string)
+
+	var modes []string
+	if d.Get("api_token").(string) != "" {
+		modes = append(modes, "api_token")
+	}
+	if email != "" || password != "" {
+		modes = append(modes, "email/password")
+	}
+	if bearerToken != "" {
+		modes = append(modes, "bearer_token")
+	}
+	if len(modes) > 1 {
+		return "", "", "", diag.Errorf("only one of api_token, email/password and "+
+			"bearer_token can be configured, got %s (check the YBA_API_TOKEN, "+
+			"YBA_EMAIL and YBA_BEARER_TOKEN environment variables too)",
+			strings.Join(modes, ", "))
+	}
+	if (email == "") != (password == "") {
+		return "", "", "", diag.Errorf("email and password must be set together")
+	}
+	return email, password, bearerToken, nil
+}
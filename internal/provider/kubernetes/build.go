@@ -0,0 +1,249 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kubernetes
+
+import (
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+// kubernetesProviderTypes are the cloud types accepted by yba-cli --type
+var kubernetesProviderTypes = []string{"aks", "eks", "gke", "custom"}
+
+// kubeconfigName is the file name YBA stores a kubeconfig under. yba-cli uses the
+// base name of the uploaded file; scope keeps the names of the provider and zone
+// kubeconfigs apart.
+func kubeconfigName(scope string) string {
+	return scope + "-kubeconfig.conf"
+}
+
+// buildKubernetesCloudInfo builds Kubernetes cloud info from schema
+// Mirrors yba-cli: k8sCloudInfo construction in create_provider.go
+func buildKubernetesCloudInfo(d *schema.ResourceData) *client.KubernetesInfo {
+	return mergeKubernetesCloudInfo(client.KubernetesInfo{}, d)
+}
+
+// mergeKubernetesCloudInfo sets the configured fields on info, keeping the
+// fields YBA populated (e.g. the path of the stored kubeconfig) as they are.
+// The kubeconfig and pull secret are sent whenever set, since YBA does not
+// return their contents.
+func mergeKubernetesCloudInfo(
+	info client.KubernetesInfo,
+	d *schema.ResourceData,
+) *client.KubernetesInfo {
+	info.SetKubernetesProvider(d.Get("kubernetes_provider_type").(string))
+	info.SetKubernetesStorageClass(d.Get("storage_class").(string))
+	if v := d.Get("image_registry").(string); v != "" {
+		info.SetKubernetesImageRegistry(v)
+	}
+	if v := d.Get("kubeconfig_content").(string); v != "" {
+		info.SetKubeConfigContent(v)
+		info.SetKubeConfigName(kubeconfigName(d.Get("name").(string)))
+	}
+	if v := d.Get("pull_secret_content").(string); v != "" {
+		info.SetKubernetesPullSecretContent(v)
+		info.SetKubernetesPullSecretName(d.Get("pull_secret_name").(string))
+	}
+	return &info
+}
+
+// buildKubernetesRegionInfo builds the region or zone level overrides of the
+// Kubernetes cloud info. Only a zone has kubeconfig_content.
+func buildKubernetesRegionInfo(
+	m map[string]interface{},
+	scope string,
+) *client.KubernetesRegionInfo {
+	info := &client.KubernetesRegionInfo{}
+	if v, _ := m["namespace"].(string); v != "" {
+		info.SetKubeNamespace(v)
+	}
+	if v, _ := m["storage_class"].(string); v != "" {
+		info.SetKubernetesStorageClass(v)
+	}
+	if v, _ := m["overrides"].(string); v != "" {
+		info.SetOverrides(v)
+	}
+	if v, _ := m["kubeconfig_content"].(string); v != "" {
+		info.SetKubeConfigContent(v)
+		info.SetKubeConfigName(kubeconfigName(scope))
+	}
+	return info
+}
+
+// buildKubernetesRegions builds Kubernetes regions from schema
+// Mirrors yba-cli: buildK8sRegions in create_provider.go
+func buildKubernetesRegions(regions []interface{}) []client.Region {
+	result := make([]client.Region, 0)
+
+	for _, r := range regions {
+		regionMap := r.(map[string]interface{})
+		regionCode := regionMap["code"].(string)
+
+		zonesList, _ := regionMap["zones"].([]interface{})
+
+		region := client.Region{
+			Code:  utils.GetStringPointer(regionCode),
+			Name:  utils.GetStringPointer(regionCode),
+			Zones: buildKubernetesZones(zonesList),
+			Details: &client.RegionDetails{
+				CloudInfo: &client.RegionCloudInfo{
+					Kubernetes: buildKubernetesRegionInfo(regionMap, regionCode),
+				},
+			},
+		}
+
+		// Include UUID for existing regions (needed for updates)
+		if uuid, ok := regionMap["uuid"].(string); ok && uuid != "" {
+			region.Uuid = utils.GetStringPointer(uuid)
+		}
+
+		result = append(result, region)
+	}
+
+	return result
+}
+
+// buildKubernetesZones builds zones for a region
+// Mirrors yba-cli: buildK8sZones in create_provider.go
+func buildKubernetesZones(zones []interface{}) []client.AvailabilityZone {
+	result := make([]client.AvailabilityZone, 0)
+
+	for _, z := range zones {
+		zoneMap := z.(map[string]interface{})
+		zoneCode := zoneMap["code"].(string)
+
+		zone := client.AvailabilityZone{
+			Code: utils.GetStringPointer(zoneCode),
+			Name: zoneCode,
+			Details: &client.AvailabilityZoneDetails{
+				CloudInfo: &client.AZCloudInfo{
+					Kubernetes: buildKubernetesRegionInfo(zoneMap, zoneCode),
+				},
+			},
+		}
+
+		// Include UUID for existing zones (needed for updates)
+		if uuid, ok := zoneMap["uuid"].(string); ok && uuid != "" {
+			zone.Uuid = utils.GetStringPointer(uuid)
+		}
+
+		result = append(result, zone)
+	}
+
+	return result
+}
+
+// mergeRegionUUIDs merges UUIDs from old state into new config regions and
+// deactivates the regions removed from the config (like yba-cli does).
+func mergeRegionUUIDs(
+	oldRegions []interface{},
+	newRegions []interface{},
+) []client.Region {
+	oldByCode := make(map[string]map[string]interface{})
+	for _, r := range oldRegions {
+		regionMap := r.(map[string]interface{})
+		oldByCode[regionMap["code"].(string)] = regionMap
+	}
+
+	result := make([]client.Region, 0, len(newRegions))
+	for _, nr := range newRegions {
+		newMap := nr.(map[string]interface{})
+		regionCode := newMap["code"].(string)
+
+		region := buildKubernetesRegions([]interface{}{withoutUUID(newMap)})[0]
+		newZones, _ := newMap["zones"].([]interface{})
+		if oldRegion, exists := oldByCode[regionCode]; exists {
+			if uuid, _ := oldRegion["uuid"].(string); uuid != "" {
+				region.Uuid = utils.GetStringPointer(uuid)
+			}
+			oldZones, _ := oldRegion["zones"].([]interface{})
+			region.Zones = mergeZoneUUIDs(oldZones, newZones)
+			delete(oldByCode, regionCode)
+		}
+		result = append(result, region)
+	}
+
+	for code, oldRegion := range oldByCode {
+		oldZones, _ := oldRegion["zones"].([]interface{})
+		region := client.Region{
+			Code:   utils.GetStringPointer(code),
+			Name:   utils.GetStringPointer(code),
+			Active: utils.GetBoolPointer(false),
+			Zones:  mergeZoneUUIDs(oldZones, oldZones),
+		}
+		if uuid, _ := oldRegion["uuid"].(string); uuid != "" {
+			region.Uuid = utils.GetStringPointer(uuid)
+		}
+		result = append(result, region)
+	}
+
+	return result
+}
+
+// mergeZoneUUIDs merges UUIDs from old state zones into new config zones.
+// Also marks removed zones as inactive (like yba-cli does).
+func mergeZoneUUIDs(
+	oldZones []interface{},
+	newZones []interface{},
+) []client.AvailabilityZone {
+	oldByCode := make(map[string]map[string]interface{})
+	for _, z := range oldZones {
+		zoneMap := z.(map[string]interface{})
+		oldByCode[zoneMap["code"].(string)] = zoneMap
+	}
+
+	result := make([]client.AvailabilityZone, 0, len(newZones))
+	for _, nz := range newZones {
+		newMap := nz.(map[string]interface{})
+		zoneCode := newMap["code"].(string)
+
+		zone := buildKubernetesZones([]interface{}{withoutUUID(newMap)})[0]
+		if oldZone, exists := oldByCode[zoneCode]; exists {
+			if uuid, _ := oldZone["uuid"].(string); uuid != "" {
+				zone.Uuid = utils.GetStringPointer(uuid)
+			}
+			delete(oldByCode, zoneCode)
+		}
+		result = append(result, zone)
+	}
+
+	for code, oldZone := range oldByCode {
+		zone := client.AvailabilityZone{
+			Code:   utils.GetStringPointer(code),
+			Name:   code,
+			Active: utils.GetBoolPointer(false),
+		}
+		if uuid, _ := oldZone["uuid"].(string); uuid != "" {
+			zone.Uuid = utils.GetStringPointer(uuid)
+		}
+		result = append(result, zone)
+	}
+
+	return result
+}
+
+// withoutUUID returns a copy of a region or zone map without its uuid. During an
+// update the uuid in config follows the list position, not the code, so it is
+// taken from the old state instead.
+func withoutUUID(m map[string]interface{}) map[string]interface{} {
+	c := maps.Clone(m)
+	delete(c, "uuid")
+	return c
+}
@@ -0,0 +1,178 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kubernetes
+
+import (
+	"testing"
+)
+
+func TestBuildKubernetesRegions(t *testing.T) {
+	input := []interface{}{
+		map[string]interface{}{
+			"code":          "us-west1",
+			"namespace":     "yb-west",
+			"storage_class": "",
+			"overrides":     "resource:\n  master:\n    requests:\n      cpu: 2\n",
+			"zones": []interface{}{
+				map[string]interface{}{
+					"code":               "us-west1-a",
+					"namespace":          "yb-west-a",
+					"storage_class":      "premium-rwo",
+					"overrides":          "",
+					"kubeconfig_content": "apiVersion: v1",
+				},
+				map[string]interface{}{
+					"code":               "us-west1-b",
+					"namespace":          "",
+					"storage_class":      "",
+					"overrides":          "",
+					"kubeconfig_content": "",
+				},
+			},
+		},
+	}
+
+	result := buildKubernetesRegions(input)
+	if len(result) != 1 {
+		t.Fatalf("expected 1 region, got %d", len(result))
+	}
+
+	region := result[0]
+	if region.GetCode() != "us-west1" || region.GetName() != "us-west1" {
+		t.Errorf("expected code and name us-west1, got %q and %q",
+			region.GetCode(), region.GetName())
+	}
+	if region.Uuid != nil {
+		t.Errorf("expected no UUID for a new region, got %q", region.GetUuid())
+	}
+	regionDetails := region.GetDetails()
+	regionCloudInfo := regionDetails.GetCloudInfo()
+	regionInfo := regionCloudInfo.GetKubernetes()
+	if regionInfo.GetKubeNamespace() != "yb-west" {
+		t.Errorf("expected region namespace yb-west, got %q", regionInfo.GetKubeNamespace())
+	}
+	if regionInfo.HasKubernetesStorageClass() {
+		t.Errorf("expected no region storage class, got %q",
+			regionInfo.GetKubernetesStorageClass())
+	}
+	if regionInfo.GetOverrides() == "" {
+		t.Error("expected region overrides to be set")
+	}
+
+	zones := region.GetZones()
+	if len(zones) != 2 {
+		t.Fatalf("expected 2 zones, got %d", len(zones))
+	}
+	zoneDetails := zones[0].GetDetails()
+	zoneCloudInfo := zoneDetails.GetCloudInfo()
+	zoneInfo := zoneCloudInfo.GetKubernetes()
+	if zoneInfo.GetKubeNamespace() != "yb-west-a" {
+		t.Errorf("expected zone namespace yb-west-a, got %q", zoneInfo.GetKubeNamespace())
+	}
+	if zoneInfo.GetKubernetesStorageClass() != "premium-rwo" {
+		t.Errorf("expected zone storage class premium-rwo, got %q",
+			zoneInfo.GetKubernetesStorageClass())
+	}
+	if zoneInfo.GetKubeConfigContent() != "apiVersion: v1" {
+		t.Errorf("expected zone kubeconfig content, got %q", zoneInfo.GetKubeConfigContent())
+	}
+	if zoneInfo.GetKubeConfigName() != "us-west1-a-kubeconfig.conf" {
+		t.Errorf("expected zone kubeconfig name us-west1-a-kubeconfig.conf, got %q",
+			zoneInfo.GetKubeConfigName())
+	}
+
+	emptyDetails := zones[1].GetDetails()
+	emptyCloudInfo := emptyDetails.GetCloudInfo()
+	emptyInfo := emptyCloudInfo.GetKubernetes()
+	if emptyInfo.HasKubeNamespace() || emptyInfo.HasKubeConfigContent() ||
+		emptyInfo.HasKubeConfigName() {
+		t.Error("expected no overrides for a zone without namespace or kubeconfig")
+	}
+}
+
+func TestMergeRegionUUIDs(t *testing.T) {
+	oldRegions := []interface{}{
+		map[string]interface{}{
+			"uuid": "region-west",
+			"code": "us-west1",
+			"zones": []interface{}{
+				map[string]interface{}{"uuid": "zone-a", "code": "us-west1-a"},
+				map[string]interface{}{"uuid": "zone-b", "code": "us-west1-b"},
+			},
+		},
+		map[string]interface{}{
+			"uuid": "region-east",
+			"code": "us-east1",
+			"zones": []interface{}{
+				map[string]interface{}{"uuid": "zone-east", "code": "us-east1-b"},
+			},
+		},
+	}
+	// us-west1 moved to the second position, so the positional UUIDs in config
+	// belong to the other region; us-east1 was removed and us-central1 added.
+	newRegions := []interface{}{
+		map[string]interface{}{
+			"uuid": "region-west",
+			"code": "us-central1",
+			"zones": []interface{}{
+				map[string]interface{}{"uuid": "zone-a", "code": "us-central1-a"},
+			},
+		},
+		map[string]interface{}{
+			"uuid":      "region-east",
+			"code":      "us-west1",
+			"namespace": "yb",
+			"zones": []interface{}{
+				map[string]interface{}{"uuid": "zone-east", "code": "us-west1-a"},
+			},
+		},
+	}
+
+	result := mergeRegionUUIDs(oldRegions, newRegions)
+	if len(result) != 3 {
+		t.Fatalf("expected 3 regions, got %d", len(result))
+	}
+
+	central := result[0]
+	if central.Uuid != nil {
+		t.Errorf("expected no UUID for new region us-central1, got %q", central.GetUuid())
+	}
+	if central.GetZones()[0].Uuid != nil {
+		t.Error("expected no UUID for the zone of a new region")
+	}
+
+	west := result[1]
+	if west.GetUuid() != "region-west" {
+		t.Errorf("expected us-west1 to keep UUID region-west, got %q", west.GetUuid())
+	}
+	westZones := west.GetZones()
+	if len(westZones) != 2 {
+		t.Fatalf("expected 2 zones in us-west1 (1 active, 1 removed), got %d", len(westZones))
+	}
+	if westZones[0].GetUuid() != "zone-a" || westZones[0].HasActive() {
+		t.Errorf("expected active zone us-west1-a with UUID zone-a, got %q",
+			westZones[0].GetUuid())
+	}
+	if westZones[1].GetCode() != "us-west1-b" || westZones[1].GetActive() {
+		t.Errorf("expected removed zone us-west1-b to be inactive, got %q active=%t",
+			westZones[1].GetCode(), westZones[1].GetActive())
+	}
+
+	east := result[2]
+	if east.GetUuid() != "region-east" || !east.HasActive() || east.GetActive() {
+		t.Errorf("expected removed region us-east1 to be inactive with UUID region-east")
+	}
+}
@@ -0,0 +1,120 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kubernetes
+
+import (
+	client "github.com/yugabyte/platform-go-client"
+)
+
+// flattenKubernetesRegions converts API regions to schema format.
+// Regions explicitly deactivated by YBA (Active=false) are excluded so that
+// removing a region from config does not produce a perpetual diff.
+func flattenKubernetesRegions(regions []client.Region) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0)
+
+	for _, region := range regions {
+		if region.HasActive() && !region.GetActive() {
+			continue
+		}
+
+		details := region.GetDetails()
+		cloudInfo := details.GetCloudInfo()
+		r := flattenKubernetesRegionInfo(cloudInfo.GetKubernetes())
+		r["uuid"] = region.GetUuid()
+		r["code"] = region.GetCode()
+		r["name"] = region.GetName()
+		r["zones"] = flattenKubernetesZones(region.GetZones())
+
+		result = append(result, r)
+	}
+
+	return result
+}
+
+// flattenKubernetesZones converts API zones to schema format, excluding the
+// zones explicitly deactivated by YBA for the same reason as regions.
+// kubeconfig_content is left empty: YBA only returns the path it stored the
+// kubeconfig at. See preserveZoneKubeconfigs.
+func flattenKubernetesZones(zones []client.AvailabilityZone) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0)
+
+	for _, zone := range zones {
+		if zone.HasActive() && !zone.GetActive() {
+			continue
+		}
+
+		details := zone.GetDetails()
+		cloudInfo := details.GetCloudInfo()
+		z := flattenKubernetesRegionInfo(cloudInfo.GetKubernetes())
+		z["uuid"] = zone.GetUuid()
+		z["code"] = zone.GetCode()
+		z["name"] = zone.GetName()
+
+		result = append(result, z)
+	}
+
+	return result
+}
+
+// flattenKubernetesRegionInfo converts the region or zone level Kubernetes
+// cloud info to the fields regions and zones share.
+func flattenKubernetesRegionInfo(info client.KubernetesRegionInfo) map[string]interface{} {
+	return map[string]interface{}{
+		"namespace":     info.GetKubeNamespace(),
+		"storage_class": info.GetKubernetesStorageClass(),
+		"overrides":     info.GetOverrides(),
+	}
+}
+
+// preserveZoneKubeconfigs copies the write-only kubeconfig_content of each zone
+// from state into the flattened regions, matching regions and zones by code.
+func preserveZoneKubeconfigs(
+	regions []map[string]interface{},
+	stateRegions []interface{},
+) []map[string]interface{} {
+	kubeconfigs := make(map[string]map[string]interface{})
+	for _, sr := range stateRegions {
+		regionMap, ok := sr.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		code, _ := regionMap["code"].(string)
+		zones := make(map[string]interface{})
+		stateZones, _ := regionMap["zones"].([]interface{})
+		for _, sz := range stateZones {
+			zoneMap, ok := sz.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			zoneCode, _ := zoneMap["code"].(string)
+			zones[zoneCode] = zoneMap["kubeconfig_content"]
+		}
+		kubeconfigs[code] = zones
+	}
+
+	for _, r := range regions {
+		code, _ := r["code"].(string)
+		zones, _ := r["zones"].([]map[string]interface{})
+		for _, z := range zones {
+			zoneCode, _ := z["code"].(string)
+			if v, ok := kubeconfigs[code][zoneCode].(string); ok {
+				z["kubeconfig_content"] = v
+			}
+		}
+	}
+
+	return regions
+}
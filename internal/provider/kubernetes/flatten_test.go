@@ -0,0 +1,130 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kubernetes
+
+import (
+	"testing"
+
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+func TestFlattenKubernetesRegions(t *testing.T) {
+	regions := []client.Region{
+		{
+			Uuid: utils.GetStringPointer("region-west"),
+			Code: utils.GetStringPointer("us-west1"),
+			Name: utils.GetStringPointer("US West"),
+			Details: &client.RegionDetails{
+				CloudInfo: &client.RegionCloudInfo{
+					Kubernetes: &client.KubernetesRegionInfo{
+						KubeNamespace: utils.GetStringPointer("yb-west"),
+					},
+				},
+			},
+			Zones: []client.AvailabilityZone{
+				{
+					Uuid: utils.GetStringPointer("zone-a"),
+					Code: utils.GetStringPointer("us-west1-a"),
+					Name: "us-west1-a",
+					Details: &client.AvailabilityZoneDetails{
+						CloudInfo: &client.AZCloudInfo{
+							Kubernetes: &client.KubernetesRegionInfo{
+								KubernetesStorageClass: utils.GetStringPointer("premium-rwo"),
+								Overrides:              utils.GetStringPointer("foo: bar"),
+								KubeConfig: utils.GetStringPointer(
+									"/opt/yugabyte/keys/kubeconfig.conf"),
+							},
+						},
+					},
+				},
+				{
+					Code:   utils.GetStringPointer("us-west1-b"),
+					Name:   "us-west1-b",
+					Active: utils.GetBoolPointer(false),
+				},
+			},
+		},
+		{
+			Code:   utils.GetStringPointer("us-east1"),
+			Active: utils.GetBoolPointer(false),
+		},
+	}
+
+	result := flattenKubernetesRegions(regions)
+	if len(result) != 1 {
+		t.Fatalf("expected 1 active region, got %d", len(result))
+	}
+	region := result[0]
+	if region["uuid"] != "region-west" || region["code"] != "us-west1" ||
+		region["name"] != "US West" {
+		t.Errorf("unexpected region identity: %v", region)
+	}
+	if region["namespace"] != "yb-west" {
+		t.Errorf("expected region namespace yb-west, got %v", region["namespace"])
+	}
+
+	zones := region["zones"].([]map[string]interface{})
+	if len(zones) != 1 {
+		t.Fatalf("expected 1 active zone, got %d", len(zones))
+	}
+	zone := zones[0]
+	if zone["storage_class"] != "premium-rwo" || zone["overrides"] != "foo: bar" {
+		t.Errorf("unexpected zone overrides: %v", zone)
+	}
+	if _, ok := zone["kubeconfig_content"]; ok {
+		t.Error("expected kubeconfig_content not to be read from the API")
+	}
+}
+
+func TestPreserveZoneKubeconfigs(t *testing.T) {
+	regions := []map[string]interface{}{
+		{
+			"code": "us-west1",
+			"zones": []map[string]interface{}{
+				{"code": "us-west1-a"},
+				{"code": "us-west1-b"},
+			},
+		},
+		{
+			"code": "us-east1",
+			"zones": []map[string]interface{}{
+				{"code": "us-east1-b"},
+			},
+		},
+	}
+	stateRegions := []interface{}{
+		map[string]interface{}{
+			"code": "us-west1",
+			"zones": []interface{}{
+				map[string]interface{}{"code": "us-west1-b", "kubeconfig_content": "b"},
+				map[string]interface{}{"code": "us-west1-a", "kubeconfig_content": "a"},
+			},
+		},
+	}
+
+	result := preserveZoneKubeconfigs(regions, stateRegions)
+
+	westZones := result[0]["zones"].([]map[string]interface{})
+	if westZones[0]["kubeconfig_content"] != "a" || westZones[1]["kubeconfig_content"] != "b" {
+		t.Errorf("expected kubeconfigs to follow the zone code, got %v", westZones)
+	}
+	eastZones := result[1]["zones"].([]map[string]interface{})
+	if _, ok := eastZones[0]["kubeconfig_content"]; ok {
+		t.Error("expected no kubeconfig for a zone not in state")
+	}
+}
@@ -0,0 +1,436 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package kubernetes provides Terraform resource for Kubernetes cloud provider
+// following patterns from yba-cli cmd/provider/kubernetes
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/provider/providerutil"
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+// ResourceKubernetesProvider creates and maintains Kubernetes cloud provider resource
+// Following yba-cli pattern: yba provider kubernetes create/update/delete
+func ResourceKubernetesProvider() *schema.Resource {
+	return &schema.Resource{
+		Description: "Kubernetes Cloud Provider Resource. " +
+			"Use this resource to create and manage Kubernetes providers in " +
+			"YugabyteDB Anywhere.",
+
+		CreateContext: resourceKubernetesProviderCreate,
+		ReadContext:   resourceKubernetesProviderRead,
+		UpdateContext: resourceKubernetesProviderUpdate,
+		DeleteContext: resourceKubernetesProviderDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: providerutil.DefaultTimeouts,
+
+		Schema:        kubernetesProviderSchema(),
+		CustomizeDiff: validateKubernetesProvider,
+	}
+}
+
+func kubernetesProviderSchema() map[string]*schema.Schema {
+	// Start with common provider schema
+	s := providerutil.CommonProviderSchema()
+
+	// Pods run on the cluster's nodes: there is no machine image to set up time
+	// synchronization on and no SSH access key.
+	delete(s, "ntp_servers")
+	delete(s, "set_up_chrony")
+	delete(s, "access_key_code")
+
+	// Add Kubernetes-specific fields following yba-cli kubernetes create flags
+	s["kubernetes_provider_type"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringInSlice(kubernetesProviderTypes, false),
+		Description: "Kubernetes cloud type. Allowed values: aks, eks, gke, custom. " +
+			"Changing this value forces resource recreation.",
+	}
+	s["kubeconfig_content"] = &schema.Schema{
+		Type:      schema.TypeString,
+		Optional:  true,
+		Sensitive: true,
+		Description: "Contents of the kubeconfig used to reach the Kubernetes cluster. " +
+			"Leave empty when YugabyteDB Anywhere runs in the cluster and uses its service " +
+			"account, or when every zone sets its own kubeconfig_content. Not read back " +
+			"from YugabyteDB Anywhere. " +
+			"Stored in Terraform state - use an encrypted backend for security.",
+	}
+	s["storage_class"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Description: "Storage class of the persistent volumes of the YugabyteDB pods. " +
+			"Regions and zones can override it. Uses the cluster default when empty.",
+	}
+	s["image_registry"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		Description: "Registry to pull the YugabyteDB images from. " +
+			"YugabyteDB Anywhere defaults to quay.io/yugabyte/yugabyte.",
+	}
+	s["pull_secret_name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		RequiredWith: []string{"pull_secret_content"},
+		Description: "Name of the image pull secret, as set in the metadata of " +
+			"pull_secret_content.",
+	}
+	s["pull_secret_content"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Sensitive:    true,
+		RequiredWith: []string{"pull_secret_name"},
+		Description: "Contents of the Kubernetes secret (YAML) used to pull images from " +
+			"a private image_registry. Not read back from YugabyteDB Anywhere. " +
+			"Stored in Terraform state - use an encrypted backend for security.",
+	}
+
+	// Regions and zones (yba-cli: --region, --zone)
+	s["regions"] = kubernetesRegionsSchema()
+
+	return s
+}
+
+// kubernetesRegionsSchema returns the schema for Kubernetes regions.
+// NOTE: Using TypeList instead of TypeSet for simpler change detection.
+func kubernetesRegionsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		Description: "Regions associated with the provider.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"uuid": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Region UUID.",
+				},
+				"code": {
+					Type:     schema.TypeString,
+					Required: true,
+					Description: "Region code (e.g., us-west1). Should match the region " +
+						"of the Kubernetes cluster.",
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Region name. Read-only.",
+				},
+				"namespace": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "Namespace to create the pods of the region in. " +
+						"Zones can override it.",
+				},
+				"storage_class": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "Storage class for the region, overriding the " +
+						"provider storage_class.",
+				},
+				"overrides": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "Helm chart overrides (YAML) applied to the pods of " +
+						"the region.",
+				},
+				"zones": kubernetesZonesSchema(),
+			},
+		},
+	}
+}
+
+// kubernetesZonesSchema returns the schema for Kubernetes zones.
+// NOTE: Using TypeList instead of TypeSet for simpler change detection.
+func kubernetesZonesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		MinItems:    1,
+		Description: "Zones in this region.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"uuid": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Zone UUID.",
+				},
+				"code": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Zone code (e.g., us-west1-a).",
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Zone name. Read-only.",
+				},
+				"namespace": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "Namespace to create the pods of the zone in, overriding " +
+						"the region namespace.",
+				},
+				"storage_class": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "Storage class for the zone, overriding the region " +
+						"and provider storage_class.",
+				},
+				"overrides": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "Helm chart overrides (YAML) applied to the pods of " +
+						"the zone, on top of the region overrides.",
+				},
+				"kubeconfig_content": {
+					Type:      schema.TypeString,
+					Optional:  true,
+					Sensitive: true,
+					Description: "Contents of the kubeconfig of the cluster the zone runs " +
+						"in, overriding the provider kubeconfig_content. Not read back " +
+						"from YugabyteDB Anywhere. " +
+						"Stored in Terraform state - use an encrypted backend for security.",
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesProviderCreate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{},
+) diag.Diagnostics {
+	c, cUUID := providerutil.GetAPIClient(meta)
+
+	providerName := d.Get("name").(string)
+
+	// Build regions (yba-cli: buildK8sRegions) - TypeList returns []interface{}
+	regionsRaw, _ := d.Get("regions").([]interface{})
+
+	// Build provider request (mirrors yba-cli requestBody construction)
+	req := client.Provider{
+		Code:    utils.GetStringPointer(providerutil.K8sProviderCode),
+		Name:    utils.GetStringPointer(providerName),
+		Regions: buildKubernetesRegions(regionsRaw),
+		Details: &client.ProviderDetails{
+			AirGapInstall: utils.GetBoolPointer(d.Get("air_gap_install").(bool)),
+			CloudInfo: &client.CloudInfo{
+				Kubernetes: buildKubernetesCloudInfo(d),
+			},
+		},
+	}
+
+	r, response, err := c.CloudProvidersAPI.CreateProviders(ctx, cUUID).
+		CreateProviderRequest(req).Execute()
+	if err != nil {
+		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"Kubernetes Provider", "Create")
+		return diag.FromErr(errMessage)
+	}
+
+	d.SetId(*r.ResourceUUID)
+
+	if r.TaskUUID != nil {
+		err = providerutil.WaitForProviderTask(ctx, *r.TaskUUID, providerName, "created",
+			c, cUUID, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceKubernetesProviderRead(ctx, d, meta)
+}
+
+func resourceKubernetesProviderRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{},
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c, cUUID := providerutil.GetAPIClient(meta)
+
+	p, err := providerutil.GetProvider(ctx, c, cUUID, d.Id())
+	if err != nil {
+		// If the provider was deleted outside of Terraform, remove it from state
+		// so that Terraform can recreate it on the next apply.
+		if providerutil.IsProviderNotFoundError(err) {
+			tflog.Warn(
+				ctx,
+				fmt.Sprintf("Kubernetes Provider %s not found, removing from state: %v",
+					d.Id(), err),
+			)
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("name", p.GetName()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("version", p.GetVersion()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	details := p.GetDetails()
+	if err = d.Set("air_gap_install", details.GetAirGapInstall()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	cloudInfo := details.GetCloudInfo()
+	k8sInfo := cloudInfo.GetKubernetes()
+	if err = d.Set("kubernetes_provider_type", k8sInfo.GetKubernetesProvider()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("storage_class", k8sInfo.GetKubernetesStorageClass()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("image_registry", k8sInfo.GetKubernetesImageRegistry()); err != nil {
+		return diag.FromErr(err)
+	}
+	if k8sInfo.GetKubernetesPullSecretName() != "" {
+		if err = d.Set("pull_secret_name", k8sInfo.GetKubernetesPullSecretName()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// Note: We intentionally do NOT read kubeconfig_content (provider and zone)
+	// or pull_secret_content from the API: YBA only returns the path of the file
+	// it wrote them to. These fields are "write-only" - we preserve what's in the
+	// config/state.
+
+	// Align regions with state/config to preserve order and prevent unexpected diff warnings
+	stateRegions, _ := d.Get("regions").([]interface{})
+	regions := preserveZoneKubeconfigs(flattenKubernetesRegions(p.GetRegions()), stateRegions)
+	if err = d.Set("regions", providerutil.AlignRegions(regions, stateRegions)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceKubernetesProviderUpdate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{},
+) (diags diag.Diagnostics) {
+	c, cUUID := providerutil.GetAPIClient(meta)
+
+	// Fetch current provider state
+	p, err := providerutil.GetProvider(ctx, c, cUUID, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Always refresh state before returning. On success, Read errors are
+	// propagated. On failure, they are swallowed so the original error is preserved.
+	defer func() {
+		readDiags := resourceKubernetesProviderRead(ctx, d, meta)
+		if !diags.HasError() {
+			diags = append(diags, readDiags...)
+		}
+	}()
+
+	providerReq := *p
+	providerName := d.Get("name").(string)
+
+	if d.HasChange("name") {
+		providerReq.SetName(providerName)
+	}
+
+	// Merge UUIDs from old state into new config. The zone kubeconfigs are sent
+	// on every update since YBA does not return them.
+	oldRegionsRaw, newRegionsRaw := d.GetChange("regions")
+	oldRegions, _ := oldRegionsRaw.([]interface{})
+	newRegions, _ := newRegionsRaw.([]interface{})
+	providerReq.SetRegions(mergeRegionUUIDs(oldRegions, newRegions))
+
+	details := providerReq.GetDetails()
+	details.SetAirGapInstall(d.Get("air_gap_install").(bool))
+	cloudInfo := details.GetCloudInfo()
+	cloudInfo.SetKubernetes(*mergeKubernetesCloudInfo(cloudInfo.GetKubernetes(), d))
+	details.SetCloudInfo(cloudInfo)
+	providerReq.SetDetails(details)
+
+	r, response, err := c.CloudProvidersAPI.EditProvider(ctx, cUUID, d.Id()).
+		EditProviderRequest(providerReq).Execute()
+	if err != nil {
+		utils.RevertFields(d, "kubeconfig_content", "pull_secret_content", "regions")
+		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"Kubernetes Provider", "Update")
+		return diag.FromErr(errMessage)
+	}
+
+	if r.TaskUUID != nil {
+		err = providerutil.WaitForProviderTask(ctx, *r.TaskUUID, providerName, "updated",
+			c, cUUID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			// As with the other providers, the edit task commits the provider
+			// details before it updates regions and zones, so the write-only
+			// fields may already be persisted and are not reverted here.
+			return diag.FromErr(err)
+		}
+	}
+
+	return
+}
+
+func resourceKubernetesProviderDelete(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{},
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c, cUUID := providerutil.GetAPIClient(meta)
+
+	providerName := d.Get("name").(string)
+
+	r, response, err := c.CloudProvidersAPI.Delete(ctx, cUUID, d.Id()).Execute()
+	if err != nil {
+		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"Kubernetes Provider", "Delete")
+		return diag.FromErr(errMessage)
+	}
+
+	if r.TaskUUID != nil {
+		err = providerutil.WaitForProviderTask(ctx, *r.TaskUUID, providerName, "deleted",
+			c, cUUID, d.Timeout(schema.TimeoutDelete))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return diags
+}
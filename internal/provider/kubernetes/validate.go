@@ -0,0 +1,165 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/provider/providerutil"
+)
+
+// overrideData holds the user-provided fields a region and a zone share
+type overrideData struct {
+	namespace    string
+	storageClass string
+	overrides    string
+}
+
+// regionData holds user-provided region fields for comparison
+type regionData struct {
+	overrideData
+	zones map[string]zoneData
+}
+
+// zoneData holds user-provided zone fields for comparison
+type zoneData struct {
+	overrideData
+	kubeconfigContent string
+}
+
+// validateKubernetesProvider is the CustomizeDiff function for Kubernetes providers.
+func validateKubernetesProvider(
+	ctx context.Context,
+	d *schema.ResourceDiff,
+	meta interface{},
+) error {
+	if err := providerutil.MarkVersionComputedIfChanged(ctx, d,
+		[]string{
+			"kubeconfig_content", "storage_class", "image_registry",
+			"pull_secret_name", "pull_secret_content",
+		},
+		regionsContentChanged,
+		nil,
+	); err != nil {
+		return err
+	}
+
+	regionsRaw, _ := d.Get("regions").([]interface{})
+	return checkDuplicateRegionsOrZones(regionsRaw)
+}
+
+// checkDuplicateRegionsOrZones rejects region codes used twice in the provider
+// and zone codes used twice within a region.
+func checkDuplicateRegionsOrZones(regionsRaw []interface{}) error {
+	regionCodes := make(map[string]bool)
+
+	for _, r := range regionsRaw {
+		regionMap := r.(map[string]interface{})
+		regionCode := regionMap["code"].(string)
+
+		if regionCodes[regionCode] {
+			return fmt.Errorf(
+				"duplicate region code %q found: each region must have a unique code",
+				regionCode,
+			)
+		}
+		regionCodes[regionCode] = true
+
+		zonesList, _ := regionMap["zones"].([]interface{})
+
+		zoneCodes := make(map[string]bool)
+		for _, z := range zonesList {
+			zoneMap := z.(map[string]interface{})
+			zoneCode := zoneMap["code"].(string)
+
+			if zoneCodes[zoneCode] {
+				return fmt.Errorf(
+					"duplicate zone code %q found in region %q: "+
+						"each zone within a region must have a unique code",
+					zoneCode, regionCode,
+				)
+			}
+			zoneCodes[zoneCode] = true
+		}
+	}
+
+	return nil
+}
+
+// regionsContentChanged reports whether two region lists differ in content (ignoring order).
+func regionsContentChanged(oldRaw, newRaw interface{}) bool {
+	oldRegions := extractRegionData(oldRaw)
+	newRegions := extractRegionData(newRaw)
+
+	if len(oldRegions) != len(newRegions) {
+		return true
+	}
+
+	for regionCode, oldRegion := range oldRegions {
+		newRegion, exists := newRegions[regionCode]
+		if !exists || oldRegion.overrideData != newRegion.overrideData {
+			return true
+		}
+		if !maps.Equal(oldRegion.zones, newRegion.zones) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// extractRegionData extracts user-provided fields from regions for comparison.
+func extractRegionData(regionsRaw interface{}) map[string]regionData {
+	result := make(map[string]regionData)
+
+	regions, _ := regionsRaw.([]interface{})
+	for _, r := range regions {
+		regionMap, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		region := regionData{
+			overrideData: extractOverrideData(regionMap),
+			zones:        make(map[string]zoneData),
+		}
+		zones, _ := regionMap["zones"].([]interface{})
+		for _, z := range zones {
+			zoneMap, ok := z.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			region.zones[providerutil.GetString(zoneMap, "code")] = zoneData{
+				overrideData:      extractOverrideData(zoneMap),
+				kubeconfigContent: providerutil.GetString(zoneMap, "kubeconfig_content"),
+			}
+		}
+		result[providerutil.GetString(regionMap, "code")] = region
+	}
+
+	return result
+}
+
+func extractOverrideData(m map[string]interface{}) overrideData {
+	return overrideData{
+		namespace:    providerutil.GetString(m, "namespace"),
+		storageClass: providerutil.GetString(m, "storage_class"),
+		overrides:    providerutil.GetString(m, "overrides"),
+	}
+}
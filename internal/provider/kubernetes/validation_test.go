@@ -0,0 +1,163 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckDuplicateRegionsOrZones(t *testing.T) {
+	zones := func(codes ...string) []interface{} {
+		result := make([]interface{}, 0, len(codes))
+		for _, code := range codes {
+			result = append(result, map[string]interface{}{"code": code})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		regions  []interface{}
+		errorMsg string
+	}{
+		{
+			name: "no duplicates",
+			regions: []interface{}{
+				map[string]interface{}{"code": "us-west1", "zones": zones("us-west1-a")},
+				map[string]interface{}{"code": "us-east1", "zones": zones("us-east1-b")},
+			},
+		},
+		{
+			name: "same zone code in different regions",
+			regions: []interface{}{
+				map[string]interface{}{"code": "region-1", "zones": zones("zone-1")},
+				map[string]interface{}{"code": "region-2", "zones": zones("zone-1")},
+			},
+		},
+		{
+			name: "duplicate region codes",
+			regions: []interface{}{
+				map[string]interface{}{"code": "us-west1", "zones": zones("us-west1-a")},
+				map[string]interface{}{"code": "us-west1", "zones": zones("us-west1-b")},
+			},
+			errorMsg: "duplicate region code \"us-west1\"",
+		},
+		{
+			name: "duplicate zone codes",
+			regions: []interface{}{
+				map[string]interface{}{
+					"code":  "us-west1",
+					"zones": zones("us-west1-a", "us-west1-b", "us-west1-a"),
+				},
+			},
+			errorMsg: "duplicate zone code \"us-west1-a\" found in region \"us-west1\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkDuplicateRegionsOrZones(tt.regions)
+			if tt.errorMsg == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Errorf("expected error containing %q, got nil", tt.errorMsg)
+			} else if !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Errorf("expected error containing %q, got %q", tt.errorMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestRegionsContentChanged(t *testing.T) {
+	region := func(code, namespace string, zones ...interface{}) interface{} {
+		return map[string]interface{}{
+			"uuid":      "uuid-" + code,
+			"code":      code,
+			"namespace": namespace,
+			"zones":     zones,
+		}
+	}
+	zone := func(code, kubeconfig string) interface{} {
+		return map[string]interface{}{
+			"code":               code,
+			"kubeconfig_content": kubeconfig,
+		}
+	}
+
+	old := []interface{}{
+		region("us-west1", "yb", zone("us-west1-a", "k1")),
+		region("us-east1", "", zone("us-east1-b", "")),
+	}
+
+	tests := []struct {
+		name     string
+		new      []interface{}
+		expected bool
+	}{
+		{
+			name: "pure reorder",
+			new: []interface{}{
+				region("us-east1", "", zone("us-east1-b", "")),
+				region("us-west1", "yb", zone("us-west1-a", "k1")),
+			},
+			expected: false,
+		},
+		{
+			name: "region namespace changed",
+			new: []interface{}{
+				region("us-west1", "yb-2", zone("us-west1-a", "k1")),
+				region("us-east1", "", zone("us-east1-b", "")),
+			},
+			expected: true,
+		},
+		{
+			name: "zone kubeconfig changed",
+			new: []interface{}{
+				region("us-west1", "yb", zone("us-west1-a", "k2")),
+				region("us-east1", "", zone("us-east1-b", "")),
+			},
+			expected: true,
+		},
+		{
+			name: "zone added",
+			new: []interface{}{
+				region("us-west1", "yb", zone("us-west1-a", "k1"), zone("us-west1-b", "")),
+				region("us-east1", "", zone("us-east1-b", "")),
+			},
+			expected: true,
+		},
+		{
+			name: "region removed",
+			new: []interface{}{
+				region("us-west1", "yb", zone("us-west1-a", "k1")),
+			},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := regionsContentChanged(old, tt.new); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
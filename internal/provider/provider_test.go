@@ -16,7 +16,13 @@
 package provider
 
 import (
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/api"
 )
 
 var telemetrySinkResourceNames = []string{
@@ -60,3 +66,175 @@ func TestProviderInternalValidate(t *testing.T) {
 		t.Fatalf("provider schema is invalid: %v", err)
 	}
 }
+
+// TestBuildTLSConfigDefaults: until v2.0.0 an unset insecure_skip_verify keeps
+// the old unverified behaviour, but a configured CA or an explicit false
+// (here via YBA_INSECURE_SKIP_VERIFY) must turn verification on.
+func TestBuildTLSConfigDefaults(t *testing.T) {
+	cases := []struct {
+		name     string
+		env      string
+		raw      map[string]interface{}
+		wantSkip bool
+	}{
+		{"unset, no CA", "", map[string]interface{}{}, true},
+		{"unset, CA", "", map[string]interface{}{"ca_cert_pem": "pem"}, false},
+		{"env false", "false", map[string]interface{}{}, false},
+		{"env true, CA", "true", map[string]interface{}{"ca_cert_pem": "pem"}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(insecureSkipVerifyEnv, tc.env)
+			d := schema.TestResourceDataRaw(t, New().Schema, tc.raw)
+			cfg, diags := buildTLSConfig(d)
+			if diags.HasError() {
+				t.Fatalf("buildTLSConfig: %v", diags)
+			}
+			if cfg.InsecureSkipVerify != tc.wantSkip {
+				t.Errorf("InsecureSkipVerify = %t, want %t", cfg.InsecureSkipVerify, tc.wantSkip)
+			}
+		})
+	}
+}
+
+func TestBuildTLSConfigRejectsBadEnv(t *testing.T) {
+	t.Setenv(insecureSkipVerifyEnv, "maybe")
+	d := schema.TestResourceDataRaw(t, New().Schema, map[string]interface{}{})
+	if _, diags := buildTLSConfig(d); !diags.HasError() {
+		t.Error("expected an error for a non-boolean YBA_INSECURE_SKIP_VERIFY")
+	}
+}
+
+func TestCredentialsSingleMode(t *testing.T) {
+	for _, env := range []string{"YBA_API_TOKEN", "YBA_API_KEY", "YB_API_KEY", "YBA_EMAIL",
+		"YBA_PASSWORD", "YBA_BEARER_TOKEN"} {
+		t.Setenv(env, "")
+	}
+	cases := []struct {
+		name    string
+		raw     map[string]interface{}
+		wantErr bool
+	}{
+		{"api token", map[string]interface{}{"api_token": "tok"}, false},
+		{"login", map[string]interface{}{"email": "a@b.c", "password": "p"}, false},
+		{"bearer", map[string]interface{}{"bearer_token": "jwt"}, false},
+		{"token and login", map[string]interface{}{
+			"api_token": "tok", "email": "a@b.c", "password": "p",
+		}, true},
+		{"login and bearer", map[string]interface{}{
+			"bearer_token": "jwt", "email": "a@b.c", "password": "p",
+		}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, New().Schema, tc.raw)
+			_, _, _, diags := credentials(d)
+			if diags.HasError() != tc.wantErr {
+				t.Errorf("credentials() diags = %v, wantErr %t", diags, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildRetryConfig(t *testing.T) {
+	for _, env := range []string{"YBA_MAX_RETRIES", "YBA_RETRY_WAIT_MIN", "YBA_RETRY_WAIT_MAX"} {
+		t.Setenv(env, "")
+	}
+	cases := []struct {
+		name    string
+		raw     map[string]interface{}
+		want    api.RetryConfig
+		wantErr bool
+	}{
+		{"defaults", map[string]interface{}{}, api.DefaultRetryConfig, false},
+		{"disabled", map[string]interface{}{"max_retries": 0},
+			api.RetryConfig{WaitMin: time.Second, WaitMax: 30 * time.Second}, false},
+		{"custom", map[string]interface{}{
+			"max_retries": 2, "retry_wait_min": "500ms", "retry_wait_max": "1m",
+		}, api.RetryConfig{MaxRetries: 2, WaitMin: 500 * time.Millisecond,
+			WaitMax: time.Minute}, false},
+		{"bad duration", map[string]interface{}{"retry_wait_min": "soon"},
+			api.RetryConfig{}, true},
+		{"max below min", map[string]interface{}{
+			"retry_wait_min": "10s", "retry_wait_max": "1s",
+		}, api.RetryConfig{}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, New().Schema, tc.raw)
+			got, err := buildRetryConfig(d)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("buildRetryConfig() err = %v, wantErr %t", err, tc.wantErr)
+			}
+			if !tc.wantErr && got != tc.want {
+				t.Errorf("buildRetryConfig() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBuildRateLimitConfig(t *testing.T) {
+	t.Setenv("YBA_MAX_REQUESTS_PER_SECOND", "")
+	t.Setenv("YBA_MAX_CONCURRENT_REQUESTS", "")
+	d := schema.TestResourceDataRaw(t, New().Schema, map[string]interface{}{})
+	if got, err := buildRateLimitConfig(d); err != nil || got != (api.RateLimitConfig{}) {
+		t.Errorf("defaults = (%+v, %v), want unlimited", got, err)
+	}
+	d = schema.TestResourceDataRaw(t, New().Schema, map[string]interface{}{
+		"max_requests_per_second": 2.5, "max_concurrent_requests": 4,
+	})
+	want := api.RateLimitConfig{RequestsPerSecond: 2.5, MaxConcurrent: 4}
+	if got, err := buildRateLimitConfig(d); err != nil || got != want {
+		t.Errorf("buildRateLimitConfig() = (%+v, %v), want %+v", got, err, want)
+	}
+	d = schema.TestResourceDataRaw(t, New().Schema, map[string]interface{}{
+		"max_concurrent_requests": -1,
+	})
+	if _, err := buildRateLimitConfig(d); err == nil {
+		t.Error("expected an error for a negative max_concurrent_requests")
+	}
+}
+
+func TestBuildCacheTTL(t *testing.T) {
+	t.Setenv("YBA_CACHE_TTL", "")
+	cases := []struct {
+		name    string
+		raw     map[string]interface{}
+		want    time.Duration
+		wantErr bool
+	}{
+		{"default", map[string]interface{}{}, api.DefaultCacheTTL, false},
+		{"custom", map[string]interface{}{"cache_ttl": "5s"}, 5 * time.Second, false},
+		{"disabled", map[string]interface{}{"cache_ttl": "0"}, 0, false},
+		{"bad duration", map[string]interface{}{"cache_ttl": "soon"}, 0, true},
+		{"negative", map[string]interface{}{"cache_ttl": "-1s"}, 0, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, New().Schema, tc.raw)
+			got, err := buildCacheTTL(d)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("buildCacheTTL() err = %v, wantErr %t", err, tc.wantErr)
+			}
+			if !tc.wantErr && got != tc.want {
+				t.Errorf("buildCacheTTL() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDefaultTags(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, New().Schema, map[string]interface{}{})
+	if got := defaultTags(d); len(got) != 0 {
+		t.Errorf("defaults = %v, want none", got)
+	}
+	d = schema.TestResourceDataRaw(t, New().Schema, map[string]interface{}{
+		"default_tags": []interface{}{map[string]interface{}{
+			"tags": map[string]interface{}{"team": "db", "env": "prod"},
+		}},
+	})
+	want := map[string]string{"team": "db", "env": "prod"}
+	if got := defaultTags(d); !reflect.DeepEqual(got, want) {
+		t.Errorf("defaultTags() = %v, want %v", got, want)
+	}
+}
@@ -1129,7 +1129,7 @@ func dispatchExportTelemetryConfig(
 	label := fmt.Sprintf("Configure Telemetry on Universe %s (%s)",
 		universeUUID, operation)
 	return utils.DispatchAndWait(ctx, label,
-		apiClient.CustomerID, apiClient.YugawareClient, timeout,
+		apiClient.CustomerID, apiClient.YugawareClient, timeout, apiClient.AbortTasksOnCancel,
 		utils.ResourceEntity, "Universe Telemetry Config", operation,
 		func() (string, *http.Response, error) {
 			task, resp, err := apiClient.YugawareClientV2.UniverseAPI.
@@ -34,6 +34,9 @@ func buildUniverse(d *schema.ResourceData) client.UniverseConfigureTaskParams {
 	clustersRaw, _ := d.Get("clusters").([]interface{})
 	clusters := buildClusters(clustersRaw)
 	alignSpecificGFlagsWithHCL(clusters, d.GetRawConfig())
+	if all, ok := d.Get("instance_tags_all").([]interface{}); ok {
+		applyInstanceTagsAll(clusters, all)
+	}
 	enableYbc := true
 	rootCA, _ := d.Get("root_ca").(string)
 	clientRootCA, _ := d.Get("client_root_ca").(string)
@@ -786,6 +789,7 @@ func buildUserIntent(ui map[string]interface{}) client.UserIntent {
 		MasterGFlags:              utils.StringMap(ui["master_gflags"].(map[string]interface{})),
 	}
 	intent.SpecificGFlags = buildSpecificGFlags(ui["specific_gflags"].([]interface{}))
+	buildKubernetesIntent(&intent, ui)
 	// dedicated_masters block presence drives DedicatedNodes.
 	// An empty block means: dedicated mode, fall back to TServer instance/device.
 	// Terraform SDK v2 may pass []interface{}{nil} for an empty block that has
@@ -136,15 +136,6 @@ func planCertRotations(
 	return plan
 }
 
-// triggerFired reports whether the given cert_rotation trigger changed to a
-// non-empty value in this update. First-time set fires; clearing does not.
-func triggerFired(d *schema.ResourceData, key string) bool {
-	if !d.HasChange(key) {
-		return false
-	}
-	return d.Get(key).(string) != ""
-}
-
 // performCertRotations runs at the tail of resourceUniverseUpdate — after all
 // cluster-level operations including the TLS toggle — and dispatches up to two
 // sequential upgrade/certs tasks: a RootCert rotation for root_ca /
@@ -162,8 +153,8 @@ func performCertRotations(
 	c := meta.(*api.APIClient).YugawareClient
 	cUUID := meta.(*api.APIClient).CustomerID
 
-	serverTrigger := triggerFired(d, "cert_rotation.0.server_cert_trigger")
-	clientTrigger := triggerFired(d, "cert_rotation.0.client_cert_trigger")
+	serverTrigger := utils.TriggerFired(d, "cert_rotation.0.server_cert_trigger")
+	clientTrigger := utils.TriggerFired(d, "cert_rotation.0.client_cert_trigger")
 
 	// Cheap exit before any API call when nothing cert-related is in the plan.
 	if !d.HasChange("root_ca") && !d.HasChange("client_root_ca") &&
@@ -299,9 +290,10 @@ func dispatchCertsRotate(
 ) diag.Diagnostics {
 	c := meta.(*api.APIClient).YugawareClient
 	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
 
 	return utils.DispatchAndWait(ctx, label, cUUID, c,
-		d.Timeout(schema.TimeoutUpdate),
+		d.Timeout(schema.TimeoutUpdate), abortOnCancel,
 		utils.ResourceEntity, "Universe", fmt.Sprintf("Update - %s", label),
 		func() (string, *http.Response, error) {
 			r, resp, e := c.UniverseUpgradesManagementAPI.UpgradeCerts(
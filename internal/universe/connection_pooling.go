@@ -0,0 +1,225 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package universe
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+// connectionPoolingSchema is the connection_pooling block of a cluster's
+// user_intent.
+func connectionPoolingSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		MaxItems: 1,
+		Optional: true,
+		Computed: true,
+		Description: "YSQL connection pooling with the YugabyteDB connection manager. " +
+			"Only valid on the PRIMARY cluster; it applies to the whole universe. Changes " +
+			"dispatch the YugabyteDB Anywhere connection pooling task, which restarts the " +
+			"nodes as set in node_restart_settings. Read reports the state YugabyteDB " +
+			"Anywhere holds, so connection pooling changed outside Terraform shows as a " +
+			"change. Removing the block leaves connection pooling as it is.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"enabled": {
+					Type:        schema.TypeBool,
+					Required:    true,
+					Description: "Whether connection pooling is enabled. Requires enable_ysql.",
+				},
+				"gflags": {
+					Type:     schema.TypeMap,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+					Optional: true,
+					Computed: true,
+					Description: "Connection manager GFlags of the TServers (e.g. " +
+						"ysql_conn_mgr_max_client_connections). Only applied while enabled " +
+						"is true. Omitting it preserves the existing flags; to clear them, " +
+						"declare it explicitly empty (gflags = {}).",
+				},
+			},
+		},
+	}
+}
+
+// connectionPooling is the connection pooling state of a universe.
+type connectionPooling struct {
+	enabled bool
+	gflags  map[string]string
+}
+
+// liveConnectionPooling reads the connection pooling state YBA reports for the
+// PRIMARY cluster. YBA keys the connection manager flags by cluster UUID.
+func liveConnectionPooling(primary client.Cluster) connectionPooling {
+	ui := primary.UserIntent
+	live := connectionPooling{enabled: ui.GetEnableConnectionPooling()}
+	sg, ok := ui.GetConnectionPoolingGflags()[primary.GetUuid()]
+	if ok && sg.PerProcessFlags != nil {
+		live.gflags = sg.PerProcessFlags.Value["TSERVER"]
+	}
+	if live.gflags == nil {
+		live.gflags = map[string]string{}
+	}
+	return live
+}
+
+// configuredConnectionPooling reads the connection_pooling block of the
+// PRIMARY cluster. ok is false when the block is absent, in which case
+// connection pooling is left as is.
+func configuredConnectionPooling(d *schema.ResourceData) (connectionPooling, bool) {
+	for _, raw := range d.Get("clusters").([]interface{}) {
+		cl, _ := raw.(map[string]interface{})
+		if cl == nil || cl["cluster_type"] != "PRIMARY" {
+			continue
+		}
+		ui := utils.MapFromSingletonList(cl["user_intent"].([]interface{}))
+		blocks, _ := ui["connection_pooling"].([]interface{})
+		if len(blocks) == 0 || blocks[0] == nil {
+			return connectionPooling{}, false
+		}
+		block := blocks[0].(map[string]interface{})
+		gflags, _ := block["gflags"].(map[string]interface{})
+		return connectionPooling{
+			enabled: block["enabled"].(bool),
+			gflags:  *utils.StringMap(gflags),
+		}, true
+	}
+	return connectionPooling{}, false
+}
+
+// connectionPoolingDiffers reports whether live needs the connection pooling
+// task to match want. The flags only matter while pooling is enabled.
+func connectionPoolingDiffers(want, live connectionPooling) bool {
+	if want.enabled != live.enabled {
+		return true
+	}
+	return want.enabled && !maps.Equal(want.gflags, live.gflags)
+}
+
+// flattenConnectionPooling converts the connection pooling state of a cluster
+// into the connection_pooling block. It is empty for read replicas, which
+// share the setting of the PRIMARY cluster.
+func flattenConnectionPooling(cluster client.Cluster) []interface{} {
+	if cluster.GetClusterType() != "PRIMARY" {
+		return []interface{}{}
+	}
+	live := liveConnectionPooling(cluster)
+	return utils.CreateSingletonList(map[string]interface{}{
+		"enabled": live.enabled,
+		"gflags":  live.gflags,
+	})
+}
+
+// validateConnectionPooling checks the connection_pooling blocks of the
+// clusters at plan time.
+func validateConnectionPooling(clusters []interface{}) error {
+	for _, raw := range clusters {
+		cl, _ := raw.(map[string]interface{})
+		if cl == nil {
+			continue
+		}
+		ui := utils.MapFromSingletonList(cl["user_intent"].([]interface{}))
+		blocks, _ := ui["connection_pooling"].([]interface{})
+		if len(blocks) == 0 || blocks[0] == nil {
+			continue
+		}
+		if cl["cluster_type"] != "PRIMARY" {
+			return errors.New("connection_pooling is only valid on the PRIMARY cluster: " +
+				"read replicas use the connection pooling of the universe")
+		}
+		block := blocks[0].(map[string]interface{})
+		if !block["enabled"].(bool) {
+			continue
+		}
+		if enabled, _ := ui["enable_ysql"].(bool); !enabled {
+			return errors.New("connection_pooling.enabled = true requires enable_ysql = true")
+		}
+	}
+	return nil
+}
+
+// performConnectionPooling brings the universe's connection pooling in line
+// with the connection_pooling block of the PRIMARY cluster. It compares with
+// the live universe, so it is a no-op when YBA already matches.
+func performConnectionPooling(
+	ctx context.Context,
+	d *schema.ResourceData,
+	c *client.APIClient,
+	cUUID string,
+	upgradeOption string,
+	sleepAfterMasterMs, sleepAfterTServerMs int32,
+	timeout time.Duration,
+	abortOnCancel bool,
+) diag.Diagnostics {
+	want, ok := configuredConnectionPooling(d)
+	if !ok {
+		return nil
+	}
+
+	r, response, err := c.UniverseManagementAPI.GetUniverse(ctx, cUUID, d.Id()).Execute()
+	if err != nil {
+		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"Universe", "Update - Fetch universe for connection pooling")
+		return diag.FromErr(errMessage)
+	}
+	clusters := r.UniverseDetails.GetClusters()
+	primary, found := getClusterByType(clusters, "PRIMARY")
+	if !found || !connectionPoolingDiffers(want, liveConnectionPooling(primary)) {
+		return nil
+	}
+
+	tflog.Info(ctx, "Updating connection pooling", map[string]interface{}{
+		"universe_uuid": d.Id(),
+		"enabled":       want.enabled,
+	})
+	req := client.ConnectionPoolingParams{
+		Clusters:                       clusters,
+		UpgradeOption:                  upgradeOption,
+		SleepAfterMasterRestartMillis:  sleepAfterMasterMs,
+		SleepAfterTServerRestartMillis: sleepAfterTServerMs,
+	}
+	req.SetEnableConnectionPooling(want.enabled)
+	if want.enabled {
+		sg := client.NewSpecificGFlags()
+		sg.SetPerProcessFlags(*client.NewPerProcessFlags(
+			map[string]map[string]string{"TSERVER": want.gflags}))
+		req.SetConnectionPoolingGflags(map[string]client.SpecificGFlags{
+			primary.GetUuid(): *sg,
+		})
+	}
+	return utils.DispatchAndWait(ctx, "Connection Pooling", cUUID, c, timeout, abortOnCancel,
+		utils.ResourceEntity, "Universe", "Update - Connection Pooling",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.UniverseUpgradesManagementAPI.UpdateConnectionPooling(
+				ctx, cUUID, d.Id()).ConnectionPoolingParams(req).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			return r.GetTaskUUID(), resp, nil
+		},
+	)
+}
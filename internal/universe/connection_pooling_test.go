@@ -0,0 +1,135 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package universe
+
+import (
+	"reflect"
+	"testing"
+
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+func TestConnectionPoolingDiffers(t *testing.T) {
+	flags := map[string]string{"ysql_conn_mgr_max_client_connections": "5000"}
+	tests := []struct {
+		name string
+		want connectionPooling
+		live connectionPooling
+		diff bool
+	}{
+		{
+			name: "enable",
+			want: connectionPooling{enabled: true, gflags: map[string]string{}},
+			live: connectionPooling{gflags: map[string]string{}},
+			diff: true,
+		},
+		{
+			name: "disabled in the UI",
+			want: connectionPooling{enabled: true, gflags: flags},
+			live: connectionPooling{gflags: flags},
+			diff: true,
+		},
+		{
+			name: "flags changed",
+			want: connectionPooling{enabled: true, gflags: flags},
+			live: connectionPooling{enabled: true, gflags: map[string]string{}},
+			diff: true,
+		},
+		{
+			name: "in sync",
+			want: connectionPooling{enabled: true, gflags: flags},
+			live: connectionPooling{enabled: true, gflags: flags},
+		},
+		{
+			name: "flags ignored while disabled",
+			want: connectionPooling{gflags: map[string]string{}},
+			live: connectionPooling{gflags: flags},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := connectionPoolingDiffers(tt.want, tt.live); got != tt.diff {
+				t.Errorf("connectionPoolingDiffers() = %v, want %v", got, tt.diff)
+			}
+		})
+	}
+}
+
+func TestFlattenConnectionPooling(t *testing.T) {
+	sg := client.NewSpecificGFlags()
+	sg.SetPerProcessFlags(*client.NewPerProcessFlags(map[string]map[string]string{
+		"TSERVER": {"ysql_conn_mgr_max_client_connections": "5000"},
+	}))
+	primary := client.Cluster{
+		Uuid:        utils.GetStringPointer("cluster-1"),
+		ClusterType: "PRIMARY",
+		UserIntent: client.UserIntent{
+			EnableConnectionPooling: utils.GetBoolPointer(true),
+			ConnectionPoolingGflags: &map[string]client.SpecificGFlags{"cluster-1": *sg},
+		},
+	}
+	got := utils.MapFromSingletonList(flattenConnectionPooling(primary))
+	want := map[string]interface{}{
+		"enabled": true,
+		"gflags":  map[string]string{"ysql_conn_mgr_max_client_connections": "5000"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenConnectionPooling() = %v, want %v", got, want)
+	}
+
+	primary.ClusterType = "ASYNC"
+	if l := flattenConnectionPooling(primary); len(l) != 0 {
+		t.Errorf("flattenConnectionPooling() on a read replica = %v, want empty", l)
+	}
+}
+
+func TestValidateConnectionPooling(t *testing.T) {
+	cluster := func(clusterType string, ysql bool, pooling ...interface{}) interface{} {
+		return map[string]interface{}{
+			"cluster_type": clusterType,
+			"user_intent": []interface{}{map[string]interface{}{
+				"enable_ysql":        ysql,
+				"connection_pooling": pooling,
+			}},
+		}
+	}
+	enabled := map[string]interface{}{"enabled": true, "gflags": map[string]interface{}{}}
+	disabled := map[string]interface{}{"enabled": false, "gflags": map[string]interface{}{}}
+
+	tests := []struct {
+		name     string
+		clusters []interface{}
+		wantErr  bool
+	}{
+		{"enabled", []interface{}{cluster("PRIMARY", true, enabled)}, false},
+		{"absent", []interface{}{cluster("PRIMARY", false)}, false},
+		{"without ysql", []interface{}{cluster("PRIMARY", false, enabled)}, true},
+		{"disabled without ysql", []interface{}{cluster("PRIMARY", false, disabled)}, false},
+		{"on read replica", []interface{}{
+			cluster("PRIMARY", true), cluster("ASYNC", true, enabled),
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConnectionPooling(tt.clusters)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateConnectionPooling() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
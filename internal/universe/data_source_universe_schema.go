@@ -186,7 +186,8 @@ func dataSourceUniverseSchemaRead(
 	uniDetails := uni.GetUniverseDetails()
 	if uniDetails.GetUniversePaused() {
 		return diag.Errorf(
-			"universe %s is paused: resume the universe before reading its schema", uUUID)
+			"universe %s is paused: resume the universe (paused = false on yba_universe) "+
+				"before reading its schema", uUUID)
 	}
 
 	// Fetch namespaces
@@ -0,0 +1,326 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package universe
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+// Superusers whose passwords ysql_password and ycql_password set, and the
+// database YBA connects to when resetting the YSQL one.
+const (
+	ysqlAdminUsername = "yugabyte"
+	ycqlAdminUsername = "cassandra"
+	ysqlAdminDatabase = "yugabyte"
+)
+
+// dbPassword names the attributes of one of the superuser passwords.
+type dbPassword struct {
+	api       string // YSQL or YCQL
+	field     string // user_intent attribute kept in state
+	auth      string // user_intent attribute enabling the password
+	writeOnly string // write-only alternative to field
+	current   string // write-only current password for a rotation
+}
+
+var (
+	ysqlPassword = dbPassword{
+		api:       "YSQL",
+		field:     "ysql_password",
+		auth:      "enable_ysql_auth",
+		writeOnly: "ysql_password_wo",
+		current:   "ysql_current_password_wo",
+	}
+	ycqlPassword = dbPassword{
+		api:       "YCQL",
+		field:     "ycql_password",
+		auth:      "enable_ycql_auth",
+		writeOnly: "ycql_password_wo",
+		current:   "ycql_current_password_wo",
+	}
+	dbPasswords = []dbPassword{ysqlPassword, ycqlPassword}
+)
+
+// passwordRotationTriggerSchema is the password_rotation_trigger attribute.
+func passwordRotationTriggerSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Description: "Changing this value to any new non-empty value resets the YSQL " +
+			"and YCQL superuser passwords to the configured ones on the next apply. " +
+			"Needed to rotate write-only passwords, whose changes are not visible to " +
+			"Terraform; a change of ysql_password or ycql_password rotates without it. " +
+			"Setting it at universe creation records it without rotating; removing it " +
+			"never fires.",
+	}
+}
+
+// writeOnlyPasswordSchema is the write-only alternative to the p password of
+// the PRIMARY cluster.
+func writeOnlyPasswordSchema(p dbPassword) *schema.Schema {
+	return &schema.Schema{
+		Type:      schema.TypeString,
+		Optional:  true,
+		Sensitive: true,
+		WriteOnly: true,
+		Description: fmt.Sprintf("Write-only alternative to %s of the PRIMARY "+
+			"cluster, which must then be left unset: never stored in the Terraform "+
+			"plan or state. Requires Terraform 1.11+. The value is applied at creation, "+
+			"and afterwards only when password_rotation_trigger changes. The provider "+
+			"keeps no copy of the applied password, not even in private state, so "+
+			"every later rotation must also set %s to the password being replaced.",
+			p.field, p.current),
+	}
+}
+
+// currentPasswordSchema is the write-only current p password, for rotations
+// of a password state does not hold.
+func currentPasswordSchema(p dbPassword) *schema.Schema {
+	return &schema.Schema{
+		Type:      schema.TypeString,
+		Optional:  true,
+		Sensitive: true,
+		WriteOnly: true,
+		Description: fmt.Sprintf("Write-only current %s superuser password, which "+
+			"YugabyteDB Anywhere needs to reset it. Only read when a rotation fires "+
+			"and state does not hold the current password: when it was set through "+
+			"%s, or the universe was imported.", p.api, p.writeOnly),
+	}
+}
+
+// primaryClusterIndex returns the index of the PRIMARY cluster in clusters,
+// or -1 when there is none.
+func primaryClusterIndex(clusters []interface{}) int {
+	for i, raw := range clusters {
+		if cl, _ := raw.(map[string]interface{}); cl != nil && cl["cluster_type"] == "PRIMARY" {
+			return i
+		}
+	}
+	return -1
+}
+
+// writeOnlyString reads a write-only string argument from the raw config, the
+// only place its value exists. Returns "" when the argument is not set.
+func writeOnlyString(d *schema.ResourceData, name string) (string, error) {
+	v, diags := d.GetRawConfigAt(cty.GetAttrPath(name))
+	if diags.HasError() {
+		return "", fmt.Errorf("read write-only argument %s: %s", name, diags[0].Summary)
+	}
+	if v.IsNull() || !v.IsKnown() || v.Type() != cty.String {
+		return "", nil
+	}
+	return v.AsString(), nil
+}
+
+// writeOnlyConfigured reports whether a write-only argument is set in the
+// configuration at plan time. Unknown values count as set.
+func writeOnlyConfigured(config cty.Value, name string) bool {
+	if config.IsNull() || !config.IsKnown() || !config.Type().HasAttribute(name) {
+		return false
+	}
+	return !config.GetAttr(name).IsNull()
+}
+
+// validateDBPasswords checks at plan time that every enabled superuser
+// password of the PRIMARY cluster is set exactly once, in user_intent or
+// through its write-only argument.
+func validateDBPasswords(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	clusters := d.Get("clusters").([]interface{})
+	i := primaryClusterIndex(clusters)
+	if i < 0 {
+		return nil
+	}
+	ui := utils.MapFromSingletonList(
+		clusters[i].(map[string]interface{})["user_intent"].([]interface{}))
+	config := d.GetRawConfig()
+	for _, p := range dbPasswords {
+		password, _ := ui[p.field].(string)
+		writeOnly := writeOnlyConfigured(config, p.writeOnly)
+		if password != "" && writeOnly {
+			return fmt.Errorf("%s and %s cannot both be set", p.field, p.writeOnly)
+		}
+		if enabled, _ := ui[p.auth].(bool); enabled && password == "" && !writeOnly {
+			return fmt.Errorf("%s or %s is required when %s is true",
+				p.field, p.writeOnly, p.auth)
+		}
+	}
+	return nil
+}
+
+// applyWriteOnlyPasswords puts the write-only passwords into the PRIMARY
+// intent of a create request.
+func applyWriteOnlyPasswords(d *schema.ResourceData, clusters []client.Cluster) error {
+	for _, p := range dbPasswords {
+		password, err := writeOnlyString(d, p.writeOnly)
+		if err != nil {
+			return err
+		}
+		if password == "" {
+			continue
+		}
+		for i := range clusters {
+			if clusters[i].GetClusterType() != "PRIMARY" {
+				continue
+			}
+			if p == ysqlPassword {
+				clusters[i].UserIntent.SetYsqlPassword(password)
+			} else {
+				clusters[i].UserIntent.SetYcqlPassword(password)
+			}
+		}
+	}
+	return nil
+}
+
+// passwordRotation is a pending reset of one superuser password.
+type passwordRotation struct {
+	current string
+	next    string
+}
+
+// planPasswordRotation decides whether the p password of the PRIMARY cluster
+// at index i rotates in this apply: when its state-kept value changed, or
+// when password_rotation_trigger fired. The current password comes from
+// state, or from the write-only current argument when state does not hold it:
+// the SDK gives no access to private state, and a write-only password is kept
+// nowhere.
+func planPasswordRotation(
+	d *schema.ResourceData,
+	i int,
+	p dbPassword,
+) (*passwordRotation, error) {
+	prefix := fmt.Sprintf("clusters.%d.user_intent.0.", i)
+	if !d.Get(prefix + p.auth).(bool) {
+		return nil, nil
+	}
+	oldRaw, newRaw := d.GetChange(prefix + p.field)
+	old, _ := oldRaw.(string)
+	next, _ := newRaw.(string)
+	writeOnly, err := writeOnlyString(d, p.writeOnly)
+	if err != nil {
+		return nil, err
+	}
+	if writeOnly != "" {
+		next = writeOnly
+	}
+	fired := utils.TriggerFired(d, "password_rotation_trigger")
+	if !fired && (writeOnly != "" || old == next) {
+		return nil, nil
+	}
+
+	// "REDACTED" is the sentinel an import leaves in state.
+	current := old
+	if current == "" || current == "REDACTED" {
+		if current, err = writeOnlyString(d, p.current); err != nil {
+			return nil, err
+		}
+	}
+	if current == "" {
+		return nil, fmt.Errorf("cannot rotate the %s password: the current password "+
+			"is not in state, set %s", p.api, p.current)
+	}
+	return &passwordRotation{current: current, next: next}, nil
+}
+
+// revertPasswordField puts the state-kept p password of the cluster at index
+// i back to its prior value, so state does not record a password YBA never
+// accepted. The deferred Read restores passwords from this value.
+func revertPasswordField(d *schema.ResourceData, i int, p dbPassword) {
+	old, _ := d.GetChange(fmt.Sprintf("clusters.%d.user_intent.0.%s", i, p.field))
+	clusters := d.Get("clusters").([]interface{})
+	ui := utils.MapFromSingletonList(
+		clusters[i].(map[string]interface{})["user_intent"].([]interface{}))
+	ui[p.field] = old
+	_ = d.Set("clusters", clusters)
+}
+
+// buildDatabaseCredentials builds the reset request for the given rotations,
+// keyed by API. An API without a rotation is left out and keeps its password.
+func buildDatabaseCredentials(
+	rotations map[string]*passwordRotation,
+) client.DatabaseSecurityFormData {
+	req := client.DatabaseSecurityFormData{}
+	if r := rotations["YSQL"]; r != nil {
+		req.SetYsqlAdminUsername(ysqlAdminUsername)
+		req.SetYsqlCurrAdminPassword(r.current)
+		req.SetYsqlAdminPassword(r.next)
+		req.SetDbName(ysqlAdminDatabase)
+	}
+	if r := rotations["YCQL"]; r != nil {
+		req.SetYcqlAdminUsername(ycqlAdminUsername)
+		req.SetYcqlCurrAdminPassword(r.current)
+		req.SetYcqlAdminPassword(r.next)
+	}
+	return req
+}
+
+// performPasswordRotation resets the YSQL and YCQL superuser passwords of the
+// universe in one call when they rotate in this apply. The reset runs
+// synchronously and needs no restart. On failure the password fields and
+// password_rotation_trigger keep their prior values, so the next apply
+// retries.
+func performPasswordRotation(
+	ctx context.Context,
+	d *schema.ResourceData,
+	c *client.APIClient,
+	cUUID string,
+) diag.Diagnostics {
+	i := primaryClusterIndex(d.Get("clusters").([]interface{}))
+	if i < 0 {
+		return nil
+	}
+	rotations := map[string]*passwordRotation{}
+	for _, p := range dbPasswords {
+		r, err := planPasswordRotation(d, i, p)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if r != nil {
+			rotations[p.api] = r
+		}
+	}
+	if len(rotations) == 0 {
+		return nil
+	}
+
+	tflog.Info(ctx, "Rotating database superuser passwords", map[string]interface{}{
+		"universe_uuid": d.Id(),
+		"ysql":          rotations["YSQL"] != nil,
+		"ycql":          rotations["YCQL"] != nil,
+	})
+	_, response, err := c.UniverseDatabaseManagementAPI.SetDatabaseCredentials(
+		ctx, cUUID, d.Id()).DatabaseSecurityFormData(buildDatabaseCredentials(rotations)).
+		Execute()
+	if err != nil {
+		for _, p := range dbPasswords {
+			revertPasswordField(d, i, p)
+		}
+		utils.RevertFields(d, "password_rotation_trigger")
+		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"Universe", "Update - Rotate Database Passwords")
+		return diag.FromErr(errMessage)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package universe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestDBCredentialsSchemaSanity(t *testing.T) {
+	s := ResourceUniverse().Schema
+	for _, p := range dbPasswords {
+		for _, name := range []string{p.writeOnly, p.current} {
+			f, ok := s[name]
+			if !ok {
+				t.Fatalf("%s missing from universe schema", name)
+			}
+			if !f.WriteOnly || !f.Sensitive {
+				t.Errorf("%s must be WriteOnly and Sensitive: passwords must never "+
+					"land in state", name)
+			}
+			if f.Required || f.Computed || f.ForceNew {
+				t.Errorf("%s must be plain Optional", name)
+			}
+		}
+	}
+	if f := s["password_rotation_trigger"]; f == nil || f.WriteOnly || f.ForceNew {
+		t.Error("password_rotation_trigger must be a plain Optional attribute")
+	}
+}
+
+func TestBuildDatabaseCredentials(t *testing.T) {
+	req := buildDatabaseCredentials(map[string]*passwordRotation{
+		"YSQL": {current: "old-ysql", next: "new-ysql"},
+	})
+	if req.GetYsqlAdminUsername() != ysqlAdminUsername ||
+		req.GetYsqlCurrAdminPassword() != "old-ysql" ||
+		req.GetYsqlAdminPassword() != "new-ysql" ||
+		req.GetDbName() != ysqlAdminDatabase {
+		t.Errorf("unexpected YSQL credentials: %+v", req)
+	}
+	if req.YcqlAdminUsername != nil || req.YcqlAdminPassword != nil {
+		t.Error("YCQL must be left out when it does not rotate")
+	}
+
+	req = buildDatabaseCredentials(map[string]*passwordRotation{
+		"YCQL": {current: "old-ycql", next: "new-ycql"},
+	})
+	if req.GetYcqlAdminUsername() != ycqlAdminUsername ||
+		req.GetYcqlCurrAdminPassword() != "old-ycql" ||
+		req.GetYcqlAdminPassword() != "new-ycql" {
+		t.Errorf("unexpected YCQL credentials: %+v", req)
+	}
+	if req.YsqlAdminUsername != nil || req.DbName != nil {
+		t.Error("YSQL must be left out when it does not rotate")
+	}
+}
+
+func TestWriteOnlyConfigured(t *testing.T) {
+	config := cty.ObjectVal(map[string]cty.Value{
+		"ysql_password_wo":         cty.StringVal("secret"),
+		"ycql_password_wo":         cty.NullVal(cty.String),
+		"ysql_current_password_wo": cty.UnknownVal(cty.String),
+	})
+	tests := map[string]bool{
+		"ysql_password_wo":         true,
+		"ycql_password_wo":         false,
+		"ysql_current_password_wo": true,
+		"ycql_current_password_wo": false,
+	}
+	for name, want := range tests {
+		if got := writeOnlyConfigured(config, name); got != want {
+			t.Errorf("writeOnlyConfigured(%s) = %v, want %v", name, got, want)
+		}
+	}
+	if writeOnlyConfigured(cty.NullVal(config.Type()), "ysql_password_wo") {
+		t.Error("a null config has no write-only arguments")
+	}
+}
+
+func TestRestoreRedactedPasswords(t *testing.T) {
+	redacted := "REDACTED"
+	newCluster := func() map[string]interface{} {
+		r := redacted
+		return map[string]interface{}{
+			"uuid": "c1",
+			"user_intent": []interface{}{map[string]interface{}{
+				"ysql_password": &r,
+			}},
+		}
+	}
+	oldCluster := func(password string) []interface{} {
+		return []interface{}{map[string]interface{}{
+			"uuid": "c1",
+			"user_intent": []interface{}{map[string]interface{}{
+				"ysql_password": password,
+			}},
+		}}
+	}
+	ysql := func(c map[string]interface{}) interface{} {
+		return c["user_intent"].([]interface{})[0].(map[string]interface{})["ysql_password"]
+	}
+
+	nc := newCluster()
+	diags := restoreRedactedPasswords(context.Background(),
+		[]map[string]interface{}{nc}, oldCluster("kept"))
+	if diags.HasError() || len(diags) != 0 || ysql(nc) != "kept" {
+		t.Errorf("prior password must be restored, got %v (%v)", ysql(nc), diags)
+	}
+
+	nc = newCluster()
+	diags = restoreRedactedPasswords(context.Background(),
+		[]map[string]interface{}{nc}, oldCluster(""))
+	if len(diags) != 0 || ysql(nc) != "" {
+		t.Errorf("write-only password must stay out of state, got %v (%v)", ysql(nc), diags)
+	}
+
+	nc = newCluster()
+	diags = restoreRedactedPasswords(context.Background(),
+		[]map[string]interface{}{nc}, nil)
+	if len(diags) != 1 || derefPassword(ysql(nc)) != redacted {
+		t.Errorf("import must keep the sentinel with a warning, got %v (%v)", ysql(nc), diags)
+	}
+}
+
+// derefPassword dereferences a *string password left in place by restoreRedactedPasswords.
+func derefPassword(v interface{}) string {
+	if s, ok := v.(*string); ok && s != nil {
+		return *s
+	}
+	return ""
+}
+
+func TestPlanPasswordRotation(t *testing.T) {
+	universe := func(password, trigger string) map[string]interface{} {
+		return map[string]interface{}{
+			"root_ca":                   "ca-1",
+			"password_rotation_trigger": trigger,
+			"clusters": []interface{}{map[string]interface{}{
+				"cluster_type": "PRIMARY",
+				"user_intent": []interface{}{map[string]interface{}{
+					"enable_ysql_auth": true,
+					"ysql_password":    password,
+				}},
+			}},
+		}
+	}
+	writeOnly := func(next, current string) map[string]cty.Value {
+		v := map[string]cty.Value{
+			"ysql_password_wo":         cty.NullVal(cty.String),
+			"ysql_current_password_wo": cty.NullVal(cty.String),
+		}
+		if next != "" {
+			v["ysql_password_wo"] = cty.StringVal(next)
+		}
+		if current != "" {
+			v["ysql_current_password_wo"] = cty.StringVal(current)
+		}
+		return v
+	}
+	tests := []struct {
+		name      string
+		oldConfig map[string]interface{}
+		newConfig map[string]interface{}
+		writeOnly map[string]cty.Value
+		want      *passwordRotation
+		wantError bool
+	}{
+		{
+			name:      "state value first",
+			oldConfig: universe("in-state", ""),
+			newConfig: universe("next", ""),
+			writeOnly: writeOnly("", "supplied"),
+			want:      &passwordRotation{current: "in-state", next: "next"},
+		},
+		{
+			name:      "write-only rotation takes the supplied current password",
+			oldConfig: universe("", ""),
+			newConfig: universe("", "t1"),
+			writeOnly: writeOnly("next", "supplied"),
+			want:      &passwordRotation{current: "supplied", next: "next"},
+		},
+		{
+			name:      "imported password takes the supplied current password",
+			oldConfig: universe("REDACTED", ""),
+			newConfig: universe("next", ""),
+			writeOnly: writeOnly("", "supplied"),
+			want:      &passwordRotation{current: "supplied", next: "next"},
+		},
+		{
+			name:      "write-only rotation without the current password",
+			oldConfig: universe("", ""),
+			newConfig: universe("", "t1"),
+			writeOnly: writeOnly("next", ""),
+			wantError: true,
+		},
+		{
+			name:      "write-only password without a trigger",
+			oldConfig: universe("", ""),
+			newConfig: map[string]interface{}{
+				"root_ca":  "ca-2",
+				"clusters": universe("", "")["clusters"],
+			},
+			writeOnly: writeOnly("next", ""),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testUniverseUpdateData(t, tt.oldConfig, tt.newConfig, tt.writeOnly)
+			got, err := planPasswordRotation(d, 0, ysqlPassword)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("planPasswordRotation() err = %v, wantError %t", err, tt.wantError)
+			}
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("planPasswordRotation() = %+v, want no rotation", *got)
+				}
+				return
+			}
+			if got == nil || *got != *tt.want {
+				t.Errorf("planPasswordRotation() = %+v, want %+v", got, *tt.want)
+			}
+		})
+	}
+}
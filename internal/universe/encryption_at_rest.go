@@ -0,0 +1,339 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package universe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+// Operation and key types of YBA's set-universe-key request. A CMK operation
+// re-encrypts the universe key with the master key of another KMS config; a
+// DATA_KEY operation generates a new universe key.
+const (
+	earOpEnable   = "ENABLE"
+	earOpDisable  = "DISABLE"
+	earKeyCMK     = "CMK"
+	earKeyDataKey = "DATA_KEY"
+)
+
+// encryptionAtRestSchema is the encryption_at_rest block of yba_universe.
+func encryptionAtRestSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Description: "Encryption at rest of the universe data with a KMS configuration " +
+			"(see `yba_aws_kms_config` and the other KMS config resources). Changes " +
+			"dispatch the YugabyteDB Anywhere set-universe-key task, which encrypts " +
+			"the universe without restarting it. Removing the block leaves encryption " +
+			"at rest as it is; set `enabled = false` to disable it.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"kms_config_uuid": {
+					Type:     schema.TypeString,
+					Required: true,
+					Description: "UUID of the KMS configuration whose master key encrypts " +
+						"the universe key. Changing it on an encrypted universe rotates " +
+						"the master key: the universe key is re-encrypted with the new " +
+						"configuration.",
+				},
+				"enabled": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+					Description: "Whether encryption at rest is enabled. Read reports " +
+						"the state YugabyteDB Anywhere holds, so encryption disabled " +
+						"outside Terraform shows as a change back to true. True by default.",
+				},
+				"rotate_universe_key": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "Changing this to any new non-empty value generates a " +
+						"new universe key on the next apply; the value is otherwise " +
+						"opaque bookkeeping, like the `cert_rotation` triggers. Setting " +
+						"it at universe creation records it without rotating. Removing " +
+						"it never rotates. Requires `enabled = true`.",
+				},
+			},
+		},
+	}
+}
+
+// activeKMSConfigUUIDSchema is the computed active_kms_config_uuid attribute.
+func activeKMSConfigUUIDSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+		Description: "UUID of the KMS configuration encrypting the universe, empty " +
+			"when encryption at rest is disabled.",
+	}
+}
+
+// universeKeyHistorySchema is the computed universe_key_history attribute.
+func universeKeyHistorySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Description: "Universe keys YugabyteDB Anywhere generated for encryption at " +
+			"rest, oldest first. Each master key or universe key rotation adds or " +
+			"re-encrypts an entry.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"kms_config_uuid": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "UUID of the KMS configuration that encrypts the key.",
+				},
+				"db_key_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "ID of the key in the database.",
+				},
+				"active": {
+					Type:        schema.TypeBool,
+					Computed:    true,
+					Description: "Whether the key is the active universe key.",
+				},
+				"re_encryption_count": {
+					Type:     schema.TypeInt,
+					Computed: true,
+					Description: "Number of times the key was re-encrypted by a master " +
+						"key rotation.",
+				},
+				"timestamp": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Time the key was generated or last re-encrypted.",
+				},
+			},
+		},
+	}
+}
+
+// earSettings is the encryption-at-rest state of a universe: either the one
+// configured in encryption_at_rest or the one YBA reports.
+type earSettings struct {
+	enabled       bool
+	kmsConfigUUID string
+}
+
+// planEncryptionAtRest returns the set-universe-key operations, in dispatch
+// order, that take the universe from live to want.
+//
+//   - Enabling always generates a new universe key, which also satisfies a
+//     fired rotate_universe_key.
+//   - A KMS config change on an encrypted universe is a master key rotation
+//     (CMK); a fired rotate_universe_key is dispatched after it, since YBA
+//     takes one key type per task.
+//   - Disabling names the KMS config the universe is encrypted with.
+func planEncryptionAtRest(
+	want, live earSettings,
+	rotateUniverseKey bool,
+) []client.EncryptionAtRestConfig {
+	op := func(opType, kmsConfigUUID, keyType string) client.EncryptionAtRestConfig {
+		return client.EncryptionAtRestConfig{
+			OpType:        utils.GetStringPointer(opType),
+			KmsConfigUUID: utils.GetStringPointer(kmsConfigUUID),
+			Type:          utils.GetStringPointer(keyType),
+		}
+	}
+	if !want.enabled {
+		if !live.enabled {
+			return nil
+		}
+		return []client.EncryptionAtRestConfig{
+			op(earOpDisable, live.kmsConfigUUID, earKeyDataKey),
+		}
+	}
+	if !live.enabled {
+		return []client.EncryptionAtRestConfig{
+			op(earOpEnable, want.kmsConfigUUID, earKeyDataKey),
+		}
+	}
+	var ops []client.EncryptionAtRestConfig
+	if want.kmsConfigUUID != live.kmsConfigUUID {
+		ops = append(ops, op(earOpEnable, want.kmsConfigUUID, earKeyCMK))
+	}
+	if rotateUniverseKey {
+		ops = append(ops, op(earOpEnable, want.kmsConfigUUID, earKeyDataKey))
+	}
+	return ops
+}
+
+// liveEncryptionAtRest reads the encryption-at-rest state YBA reports.
+func liveEncryptionAtRest(details *client.UniverseDefinitionTaskParamsResp) earSettings {
+	config := details.GetEncryptionAtRestConfig()
+	return earSettings{
+		enabled:       config.GetEncryptionAtRestEnabled(),
+		kmsConfigUUID: config.GetKmsConfigUUID(),
+	}
+}
+
+// configuredEncryptionAtRest reads the encryption_at_rest block. ok is false
+// when the block is absent, in which case encryption at rest is left as is.
+func configuredEncryptionAtRest(d *schema.ResourceData) (earSettings, bool) {
+	blocks := d.Get("encryption_at_rest").([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return earSettings{}, false
+	}
+	block := blocks[0].(map[string]interface{})
+	return earSettings{
+		enabled:       block["enabled"].(bool),
+		kmsConfigUUID: block["kms_config_uuid"].(string),
+	}, true
+}
+
+// performEncryptionAtRest brings the universe's encryption at rest in line
+// with the encryption_at_rest block. At create, live is the freshly created
+// universe and rotate_universe_key is only recorded.
+func performEncryptionAtRest(
+	ctx context.Context,
+	d *schema.ResourceData,
+	c *client.APIClient,
+	cUUID string,
+	timeout time.Duration,
+	abortOnCancel bool,
+) diag.Diagnostics {
+	want, ok := configuredEncryptionAtRest(d)
+	if !ok || (!d.IsNewResource() && !d.HasChange("encryption_at_rest")) {
+		return nil
+	}
+	rotate := !d.IsNewResource() && utils.TriggerFired(d, "encryption_at_rest.0.rotate_universe_key")
+	if rotate && !want.enabled {
+		return diag.Errorf("encryption_at_rest.rotate_universe_key requires " +
+			"encryption_at_rest.enabled = true")
+	}
+
+	r, response, err := c.UniverseManagementAPI.GetUniverse(ctx, cUUID, d.Id()).Execute()
+	if err != nil {
+		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"Universe", "Update - Fetch universe for encryption at rest")
+		return diag.FromErr(errMessage)
+	}
+	details := r.UniverseDetails
+	for _, op := range planEncryptionAtRest(want, liveEncryptionAtRest(details), rotate) {
+		label := fmt.Sprintf("Set Universe Key (%s %s)", op.GetOpType(), op.GetType())
+		tflog.Info(ctx, label, map[string]interface{}{
+			"universe_uuid":   d.Id(),
+			"kms_config_uuid": op.GetKmsConfigUUID(),
+		})
+		req := client.EncryptionAtRestKeyParams{
+			Clusters:               details.GetClusters(),
+			EncryptionAtRestConfig: &op,
+		}
+		if diags := utils.DispatchAndWait(ctx, label, cUUID, c, timeout, abortOnCancel,
+			utils.ResourceEntity, "Universe", "Update - Encryption at Rest",
+			func() (string, *http.Response, error) {
+				r, resp, err := c.UniverseManagementAPI.SetUniverseKey(ctx, cUUID, d.Id()).
+					SetUniverseKeyRequest(req).Execute()
+				if err != nil {
+					return "", resp, err
+				}
+				return r.GetTaskUUID(), resp, nil
+			},
+		); diags != nil {
+			return diags
+		}
+	}
+	return nil
+}
+
+// readEncryptionAtRest sets active_kms_config_uuid and universe_key_history,
+// and refreshes a configured encryption_at_rest block from the live universe.
+func readEncryptionAtRest(
+	ctx context.Context,
+	d *schema.ResourceData,
+	c *client.APIClient,
+	cUUID string,
+	details *client.UniverseDefinitionTaskParamsResp,
+) error {
+	live := liveEncryptionAtRest(details)
+	active := ""
+	if live.enabled {
+		active = live.kmsConfigUUID
+	}
+	if err := d.Set("active_kms_config_uuid", active); err != nil {
+		return err
+	}
+
+	if blocks := d.Get("encryption_at_rest").([]interface{}); len(blocks) > 0 &&
+		blocks[0] != nil {
+		block := blocks[0].(map[string]interface{})
+		block["enabled"] = live.enabled
+		// A disabled universe keeps reporting its last KMS config; the
+		// configured one is what re-enabling would use.
+		if live.enabled {
+			block["kms_config_uuid"] = live.kmsConfigUUID
+		}
+		if err := d.Set("encryption_at_rest", []interface{}{block}); err != nil {
+			return err
+		}
+	}
+
+	// Universes never encrypted have no key history to fetch.
+	if live.kmsConfigUUID == "" {
+		return d.Set("universe_key_history", []interface{}{})
+	}
+	history, response, err := c.EncryptionAtRestAPI.GetKeyRefHistory(ctx, cUUID, d.Id()).
+		Execute()
+	if err != nil {
+		return utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"Universe", "Read - Universe key history")
+	}
+	return d.Set("universe_key_history", flattenUniverseKeyHistory(history))
+}
+
+// flattenUniverseKeyHistory converts YBA's KMS history entries. The key
+// reference itself is left out: it is the encrypted universe key.
+func flattenUniverseKeyHistory(history []map[string]interface{}) []interface{} {
+	keys := make([]interface{}, 0, len(history))
+	for _, entry := range history {
+		id, _ := entry["uuid"].(map[string]interface{})
+		key := map[string]interface{}{
+			"kms_config_uuid":     stringValue(entry["configUuid"]),
+			"db_key_id":           stringValue(entry["dbKeyId"]),
+			"active":              entry["active"] == true,
+			"re_encryption_count": 0,
+			"timestamp":           stringValue(entry["timestamp"]),
+		}
+		if count, ok := id["reEncryptionCount"].(float64); ok {
+			key["re_encryption_count"] = int(count)
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// stringValue formats a JSON scalar YBA returned, "" for a missing one.
+func stringValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,132 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package universe
+
+import (
+	"reflect"
+	"testing"
+)
+
+const (
+	kmsA = "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	kmsB = "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb"
+)
+
+func TestPlanEncryptionAtRest(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   earSettings
+		live   earSettings
+		rotate bool
+		ops    []string // "opType keyType kmsConfigUUID"
+	}{
+		{
+			name: "enable",
+			want: earSettings{enabled: true, kmsConfigUUID: kmsA},
+			ops:  []string{"ENABLE DATA_KEY " + kmsA},
+		},
+		{
+			name:   "enable covers rotation",
+			want:   earSettings{enabled: true, kmsConfigUUID: kmsA},
+			live:   earSettings{kmsConfigUUID: kmsA},
+			rotate: true,
+			ops:    []string{"ENABLE DATA_KEY " + kmsA},
+		},
+		{
+			name: "already enabled",
+			want: earSettings{enabled: true, kmsConfigUUID: kmsA},
+			live: earSettings{enabled: true, kmsConfigUUID: kmsA},
+		},
+		{
+			name:   "rotate universe key",
+			want:   earSettings{enabled: true, kmsConfigUUID: kmsA},
+			live:   earSettings{enabled: true, kmsConfigUUID: kmsA},
+			rotate: true,
+			ops:    []string{"ENABLE DATA_KEY " + kmsA},
+		},
+		{
+			name: "rotate master key",
+			want: earSettings{enabled: true, kmsConfigUUID: kmsB},
+			live: earSettings{enabled: true, kmsConfigUUID: kmsA},
+			ops:  []string{"ENABLE CMK " + kmsB},
+		},
+		{
+			name:   "rotate master key then universe key",
+			want:   earSettings{enabled: true, kmsConfigUUID: kmsB},
+			live:   earSettings{enabled: true, kmsConfigUUID: kmsA},
+			rotate: true,
+			ops:    []string{"ENABLE CMK " + kmsB, "ENABLE DATA_KEY " + kmsB},
+		},
+		{
+			name: "disable names the live config",
+			want: earSettings{kmsConfigUUID: kmsB},
+			live: earSettings{enabled: true, kmsConfigUUID: kmsA},
+			ops:  []string{"DISABLE DATA_KEY " + kmsA},
+		},
+		{
+			name: "already disabled",
+			want: earSettings{kmsConfigUUID: kmsA},
+			live: earSettings{kmsConfigUUID: kmsA},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, op := range planEncryptionAtRest(tt.want, tt.live, tt.rotate) {
+				got = append(got,
+					op.GetOpType()+" "+op.GetType()+" "+op.GetKmsConfigUUID())
+			}
+			if !reflect.DeepEqual(got, tt.ops) {
+				t.Errorf("planEncryptionAtRest() = %v, want %v", got, tt.ops)
+			}
+		})
+	}
+}
+
+func TestFlattenUniverseKeyHistory(t *testing.T) {
+	got := flattenUniverseKeyHistory([]map[string]interface{}{
+		{
+			"uuid": map[string]interface{}{
+				"keyRef":            "c2VjcmV0",
+				"reEncryptionCount": float64(1),
+			},
+			"configUuid": kmsB,
+			"dbKeyId":    "key-1",
+			"active":     true,
+			"timestamp":  "2026-01-02T03:04:05Z",
+		},
+		{"configUuid": kmsA},
+	})
+	want := []interface{}{
+		map[string]interface{}{
+			"kms_config_uuid":     kmsB,
+			"db_key_id":           "key-1",
+			"active":              true,
+			"re_encryption_count": 1,
+			"timestamp":           "2026-01-02T03:04:05Z",
+		},
+		map[string]interface{}{
+			"kms_config_uuid":     kmsA,
+			"db_key_id":           "",
+			"active":              false,
+			"re_encryption_count": 0,
+			"timestamp":           "",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenUniverseKeyHistory() = %v, want %v", got, want)
+	}
+}
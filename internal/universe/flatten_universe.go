@@ -53,10 +53,19 @@ func flattenClusters(clusters []client.Cluster) (res []map[string]interface{}) {
 		if cluster.PlacementInfo != nil {
 			cloudList = cluster.PlacementInfo.CloudList
 		}
+		ui := cluster.UserIntent
+		if cluster.GetClusterType() == "ASYNC" {
+			// A read replica runs no masters: drop any master resources YBA
+			// reports for it so they never trip the plan-time check.
+			ui.MasterK8SNodeResourceSpec = nil
+		}
+		userIntent := flattenUserIntent(ui)
+		userIntent[0].(map[string]interface{})["connection_pooling"] =
+			flattenConnectionPooling(cluster)
 		c := map[string]interface{}{
 			"uuid":         cluster.GetUuid(),
 			"cluster_type": cluster.ClusterType,
-			"user_intent":  flattenUserIntent(cluster.UserIntent),
+			"user_intent":  userIntent,
 			"cloud_list":   flattenCloudList(cloudList),
 		}
 		res = append(res, c)
@@ -74,6 +83,9 @@ func flattenClusters(clusters []client.Cluster) (res []map[string]interface{}) {
 //   - Index-based fallback: used on the initial Create->Read where the config
 //     clusters have no UUIDs yet (they are assigned by YBA during creation).
 //
+// A field that is empty in a matched prior cluster stays empty: the password
+// was set through its write-only argument and is kept out of state.
+//
 // Returns Warning diagnostics for any redacted field that could not be
 // restored from prior state - typically the import-bootstrap case, where the
 // "REDACTED" sentinel ends up in state and the operator needs to use
@@ -151,6 +163,12 @@ func restoreRedactedPasswords(
 				newUIMap[field] = oldVal
 				continue
 			}
+			// A prior cluster without the field keeps the password out of
+			// state: it was set through the write-only argument.
+			if oldCluster != nil {
+				newUIMap[field] = ""
+				continue
+			}
 			// No prior value to restore - the literal "REDACTED" sentinel
 			// will land in state. This is the import-bootstrap case.
 			tflog.Warn(ctx, "restoreRedactedPasswords: no prior value for redacted field",
@@ -167,11 +185,11 @@ func restoreRedactedPasswords(
 					"YBA does not return plaintext passwords. After import (or any refresh "+
 						"with no prior state for this field), state holds \"REDACTED\" for "+
 						"clusters[%d].user_intent[0].%s. The next plan will show a diff "+
-						"from \"REDACTED\" to your configured value, and the update will "+
-						"be rejected because passwords cannot be changed after universe "+
-						"creation. Add a lifecycle.ignore_changes block for this field or "+
-						"patch state with the real value. See the Import section of the "+
-						"yba_universe docs.",
+						"from \"REDACTED\" to your configured value, which is a password "+
+						"rotation that fails unless the current password is set in "+
+						"ysql_current_password_wo or ycql_current_password_wo. Add a "+
+						"lifecycle.ignore_changes block for this field or patch state with "+
+						"the real value. See the Import section of the yba_universe docs.",
 					i, field),
 			})
 		}
@@ -405,6 +423,7 @@ func flattenUserIntent(ui client.UserIntent) []interface{} {
 		"tserver_gflags":                tserverFromIntent(ui),
 		"master_gflags":                 masterFromIntent(ui),
 		"specific_gflags":               flattenSpecificGFlags(ui.SpecificGFlags),
+		"kubernetes":                    flattenKubernetes(ui),
 		"dedicated_masters":             flattenDedicatedMasters(ui),
 	}
 	return utils.CreateSingletonList(v)
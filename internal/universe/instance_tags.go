@@ -0,0 +1,384 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package universe
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/api"
+	"github.com/yugabyte/terraform-provider-yba/internal/provider/providerutil"
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+// taggableProviderCodes are the providers whose nodes YBA tags. The
+// provider's default_tags only apply to clusters on them.
+var taggableProviderCodes = []string{"aws", "gcp", "azu"}
+
+// instanceTagsAllSchema is the instance_tags_all attribute.
+func instanceTagsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Description: "Tags YugabyteDB Anywhere sets on the nodes of each cluster, in the " +
+			"order of clusters: the provider's default_tags merged with the cluster's " +
+			"instance_tags, which win on conflicts. Default tags only apply to clusters " +
+			"on AWS, GCP and Azure providers. A plan shows every tag the apply adds, " +
+			"changes or removes; the tags of an entry are set on every node of its " +
+			"cluster, the node_details_set entries with its placement_uuid.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"cluster_type": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Type of the cluster, PRIMARY or ASYNC.",
+				},
+				"tags": {
+					Type:        schema.TypeMap,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Computed:    true,
+					Description: "Tags of the nodes of the cluster.",
+				},
+			},
+		},
+	}
+}
+
+// nodeInstanceTagsSchema is the node_instance_tags attribute.
+func nodeInstanceTagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Description: "Tags of each node of the universe, ordered by node name: the " +
+			"instance_tags_all entry of the node's cluster. A plan shows the tags the " +
+			"apply sets on each existing node. It is unknown when the apply also changes " +
+			"the clusters in other ways, since the nodes can change with them.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"node_name": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Name of the node, as in node_details_set.",
+				},
+				"cluster_type": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Type of the node's cluster, PRIMARY or ASYNC.",
+				},
+				"tags": {
+					Type:        schema.TypeMap,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Computed:    true,
+					Description: "Tags of the node.",
+				},
+			},
+		},
+	}
+}
+
+// mergeInstanceTags merges the provider's default tags into the tags of a
+// cluster on a provider with the given code. The cluster's tags win.
+func mergeInstanceTags(
+	defaults map[string]string,
+	tags map[string]interface{},
+	code string,
+) map[string]interface{} {
+	merged := map[string]interface{}{}
+	if slices.Contains(taggableProviderCodes, code) {
+		for k, v := range defaults {
+			merged[k] = v
+		}
+	}
+	maps.Copy(merged, tags)
+	return merged
+}
+
+// instanceTagsAll builds instance_tags_all for the configured clusters. codes
+// holds the provider code of each cluster.
+func instanceTagsAll(
+	clusters []interface{},
+	codes []string,
+	defaults map[string]string,
+) []interface{} {
+	all := make([]interface{}, 0, len(clusters))
+	for i, raw := range clusters {
+		cl, _ := raw.(map[string]interface{})
+		ui := utils.MapFromSingletonList(cl["user_intent"].([]interface{}))
+		tags, _ := ui["instance_tags"].(map[string]interface{})
+		all = append(all, map[string]interface{}{
+			"cluster_type": cl["cluster_type"],
+			"tags":         mergeInstanceTags(defaults, tags, codes[i]),
+		})
+	}
+	return all
+}
+
+// clusterProviderCodes returns the provider code of each cluster: its
+// provider_type, or a provider lookup while that is not resolved yet. An
+// existing cluster holds provider_type in state, so only the clusters of a new
+// universe are looked up, once per provider.
+func clusterProviderCodes(
+	ctx context.Context,
+	c *client.APIClient,
+	cUUID string,
+	clusters []interface{},
+) ([]string, error) {
+	codes := make([]string, len(clusters))
+	looked := map[string]string{}
+	for i, raw := range clusters {
+		cl, _ := raw.(map[string]interface{})
+		ui := utils.MapFromSingletonList(cl["user_intent"].([]interface{}))
+		if code, _ := ui["provider_type"].(string); code != "" {
+			codes[i] = code
+			continue
+		}
+		providerUUID, _ := ui["provider"].(string)
+		if providerUUID == "" {
+			continue
+		}
+		if code, ok := looked[providerUUID]; ok {
+			codes[i] = code
+			continue
+		}
+		p, err := providerutil.GetProvider(ctx, c, cUUID, providerUUID)
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = p.GetCode()
+		looked[providerUUID] = codes[i]
+	}
+	return codes, nil
+}
+
+// nodeInstanceTags builds node_instance_tags from instance_tags_all, the
+// clusters it was built for and node_details_set. Nodes of a cluster not in
+// clusters, such as an externally managed read replica, are left out.
+func nodeInstanceTags(all, clusters, nodes []interface{}) []interface{} {
+	byPlacement := map[string]map[string]interface{}{}
+	for i, raw := range clusters {
+		cl, _ := raw.(map[string]interface{})
+		uuid, _ := cl["uuid"].(string)
+		if uuid == "" || i >= len(all) {
+			continue
+		}
+		byPlacement[uuid], _ = all[i].(map[string]interface{})
+	}
+	res := []interface{}{}
+	for _, raw := range nodes {
+		n, _ := raw.(map[string]interface{})
+		placement, _ := n["placement_uuid"].(string)
+		entry := byPlacement[placement]
+		if entry == nil {
+			continue
+		}
+		res = append(res, map[string]interface{}{
+			"node_name":    n["node_name"],
+			"cluster_type": entry["cluster_type"],
+			"tags":         entry["tags"],
+		})
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		a, _ := res[i].(map[string]interface{})["node_name"].(string)
+		b, _ := res[j].(map[string]interface{})["node_name"].(string)
+		return a < b
+	})
+	return res
+}
+
+// withoutInstanceTags returns copies of the clusters of the schema with
+// instance_tags cleared, to compare them on everything but their tags.
+func withoutInstanceTags(clusters []interface{}) []interface{} {
+	res := make([]interface{}, 0, len(clusters))
+	for _, raw := range clusters {
+		cl, _ := raw.(map[string]interface{})
+		copied := maps.Clone(cl)
+		ui := maps.Clone(utils.MapFromSingletonList(cl["user_intent"].([]interface{})))
+		delete(ui, "instance_tags")
+		copied["user_intent"] = []interface{}{ui}
+		res = append(res, copied)
+	}
+	return res
+}
+
+// planInstanceTagsAll plans instance_tags_all and node_instance_tags, so the
+// plan shows the tag changes of each cluster and each of its nodes, including
+// those of the provider's default_tags. instance_tags_all is unknown while a
+// cluster's tags or provider are; node_instance_tags also while the apply
+// changes anything else in the clusters, which can add or replace nodes.
+func planInstanceTagsAll(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if rp := d.GetRawPlan(); rp == cty.NilVal || rp.IsNull() {
+		return nil
+	}
+	clusters := d.Get("clusters").([]interface{})
+	for i := range clusters {
+		for _, field := range []string{"instance_tags", "provider"} {
+			if !d.NewValueKnown(fmt.Sprintf("clusters.%d.user_intent.0.%s", i, field)) {
+				if err := d.SetNewComputed("node_instance_tags"); err != nil {
+					return err
+				}
+				return d.SetNewComputed("instance_tags_all")
+			}
+		}
+	}
+	apiClient := meta.(*api.APIClient)
+	codes, err := clusterProviderCodes(ctx, apiClient.YugawareClient, apiClient.CustomerID,
+		clusters)
+	if err != nil {
+		return err
+	}
+	all := instanceTagsAll(clusters, codes, apiClient.DefaultTags())
+	if !reflect.DeepEqual(d.Get("instance_tags_all").([]interface{}), all) {
+		if err := d.SetNew("instance_tags_all", all); err != nil {
+			return err
+		}
+	}
+
+	// A new universe has no nodes yet: node_instance_tags stays unknown.
+	if d.Id() == "" {
+		return nil
+	}
+	oldClusters, _ := d.GetChange("clusters")
+	if !reflect.DeepEqual(withoutInstanceTags(oldClusters.([]interface{})),
+		withoutInstanceTags(clusters)) {
+		return d.SetNewComputed("node_instance_tags")
+	}
+	nodes := nodeInstanceTags(all, clusters, d.Get("node_details_set").([]interface{}))
+	if reflect.DeepEqual(d.Get("node_instance_tags").([]interface{}), nodes) {
+		return nil
+	}
+	return d.SetNew("node_instance_tags", nodes)
+}
+
+// resolveInstanceTagsAll sets instance_tags_all from the clusters being
+// applied, whose tags may have been unknown at plan time.
+func resolveInstanceTagsAll(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{},
+) error {
+	apiClient := meta.(*api.APIClient)
+	clusters := d.Get("clusters").([]interface{})
+	codes, err := clusterProviderCodes(ctx, apiClient.YugawareClient, apiClient.CustomerID,
+		clusters)
+	if err != nil {
+		return err
+	}
+	all := instanceTagsAll(clusters, codes, apiClient.DefaultTags())
+	if err := d.Set("instance_tags_all", all); err != nil {
+		return fmt.Errorf("failed to set resolved instance_tags_all: %w", err)
+	}
+	return nil
+}
+
+// applyInstanceTagsAll sets the instance tags of each cluster to its
+// instance_tags_all entry, which adds the provider's default tags.
+func applyInstanceTagsAll(clusters []client.Cluster, all []interface{}) {
+	for i := range clusters {
+		if i >= len(all) {
+			return
+		}
+		entry, _ := all[i].(map[string]interface{})
+		if entry == nil || entry["cluster_type"] != clusters[i].GetClusterType() {
+			continue
+		}
+		tags, _ := entry["tags"].(map[string]interface{})
+		clusters[i].UserIntent.InstanceTags = utils.StringMap(tags)
+	}
+}
+
+// flattenInstanceTagsAll converts the tags YBA holds for each cluster into
+// instance_tags_all.
+func flattenInstanceTagsAll(clusters []client.Cluster) []interface{} {
+	all := make([]interface{}, 0, len(clusters))
+	for _, cl := range clusters {
+		tags := map[string]interface{}{}
+		for k, v := range cl.UserIntent.GetInstanceTags() {
+			tags[k] = v
+		}
+		all = append(all, map[string]interface{}{
+			"cluster_type": cl.GetClusterType(),
+			"tags":         tags,
+		})
+	}
+	return all
+}
+
+// stripDefaultTags removes the provider's default tags from the instance_tags
+// read from YBA, so instance_tags keeps holding only the configured tags. A
+// default tag stays when the prior state of the cluster holds it too, as the
+// configuration then sets it itself.
+//
+// Cluster matching mirrors restoreRedactedPasswords: UUID-first, then index.
+func stripDefaultTags(
+	newClusters []map[string]interface{},
+	oldClusters []interface{},
+	defaults map[string]string,
+) {
+	if len(defaults) == 0 {
+		return
+	}
+	oldByUUID := make(map[string]map[string]interface{}, len(oldClusters))
+	for _, oc := range oldClusters {
+		ocMap, ok := oc.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if uuid, _ := ocMap["uuid"].(string); uuid != "" {
+			oldByUUID[uuid] = ocMap
+		}
+	}
+
+	for i, nc := range newClusters {
+		var oldCluster map[string]interface{}
+		if uuid, _ := nc["uuid"].(string); uuid != "" {
+			oldCluster = oldByUUID[uuid]
+		}
+		if oldCluster == nil && i < len(oldClusters) {
+			oldCluster, _ = oldClusters[i].(map[string]interface{})
+		}
+		var prior map[string]interface{}
+		if oldCluster != nil {
+			oldUI := utils.MapFromSingletonList(oldCluster["user_intent"].([]interface{}))
+			prior, _ = oldUI["instance_tags"].(map[string]interface{})
+		}
+
+		newUIList, ok := nc["user_intent"].([]interface{})
+		if !ok || len(newUIList) == 0 {
+			continue
+		}
+		newUI, ok := newUIList[0].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		live, _ := newUI["instance_tags"].(map[string]string)
+		tags := make(map[string]string, len(live))
+		for k, v := range live {
+			if _, configured := prior[k]; !configured && defaults[k] == v {
+				continue
+			}
+			tags[k] = v
+		}
+		newUI["instance_tags"] = tags
+	}
+}
@@ -0,0 +1,178 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package universe
+
+import (
+	"reflect"
+	"testing"
+
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+func TestMergeInstanceTags(t *testing.T) {
+	defaults := map[string]string{"team": "db", "env": "prod"}
+	tags := map[string]interface{}{"env": "staging", "app": "orders"}
+
+	got := mergeInstanceTags(defaults, tags, "aws")
+	want := map[string]interface{}{"team": "db", "env": "staging", "app": "orders"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("aws: got %v, want %v", got, want)
+	}
+
+	got = mergeInstanceTags(defaults, tags, "onprem")
+	want = map[string]interface{}{"env": "staging", "app": "orders"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("onprem: got %v, want %v", got, want)
+	}
+}
+
+func TestInstanceTagsAll(t *testing.T) {
+	cluster := func(clusterType string, tags map[string]interface{}) interface{} {
+		return map[string]interface{}{
+			"cluster_type": clusterType,
+			"user_intent": []interface{}{map[string]interface{}{
+				"instance_tags": tags,
+			}},
+		}
+	}
+	clusters := []interface{}{
+		cluster("PRIMARY", map[string]interface{}{"app": "orders"}),
+		cluster("ASYNC", nil),
+	}
+	got := instanceTagsAll(clusters, []string{"gcp", "kubernetes"},
+		map[string]string{"team": "db"})
+	want := []interface{}{
+		map[string]interface{}{
+			"cluster_type": "PRIMARY",
+			"tags":         map[string]interface{}{"team": "db", "app": "orders"},
+		},
+		map[string]interface{}{
+			"cluster_type": "ASYNC",
+			"tags":         map[string]interface{}{},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestNodeInstanceTags(t *testing.T) {
+	primaryTags := map[string]interface{}{"app": "orders"}
+	asyncTags := map[string]interface{}{"app": "reports"}
+	all := []interface{}{
+		map[string]interface{}{"cluster_type": "PRIMARY", "tags": primaryTags},
+		map[string]interface{}{"cluster_type": "ASYNC", "tags": asyncTags},
+	}
+	clusters := []interface{}{
+		map[string]interface{}{"uuid": "c-primary"},
+		map[string]interface{}{"uuid": "c-async"},
+	}
+	node := func(name, placement string) interface{} {
+		return map[string]interface{}{"node_name": name, "placement_uuid": placement}
+	}
+	nodes := []interface{}{
+		node("yb-n3", "c-async"),
+		node("yb-n2", "c-primary"),
+		node("yb-n1", "c-primary"),
+		node("yb-n4", "c-external"),
+	}
+
+	got := nodeInstanceTags(all, clusters, nodes)
+	want := []interface{}{
+		map[string]interface{}{"node_name": "yb-n1", "cluster_type": "PRIMARY",
+			"tags": primaryTags},
+		map[string]interface{}{"node_name": "yb-n2", "cluster_type": "PRIMARY",
+			"tags": primaryTags},
+		map[string]interface{}{"node_name": "yb-n3", "cluster_type": "ASYNC",
+			"tags": asyncTags},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestApplyInstanceTagsAll(t *testing.T) {
+	clusters := []client.Cluster{
+		{ClusterType: "PRIMARY", UserIntent: client.UserIntent{
+			InstanceTags: &map[string]string{"app": "orders"},
+		}},
+		{ClusterType: "ASYNC"},
+	}
+	applyInstanceTagsAll(clusters, []interface{}{
+		map[string]interface{}{
+			"cluster_type": "PRIMARY",
+			"tags":         map[string]interface{}{"team": "db", "app": "orders"},
+		},
+		// An entry of another cluster type leaves the cluster alone.
+		map[string]interface{}{
+			"cluster_type": "PRIMARY",
+			"tags":         map[string]interface{}{"team": "db"},
+		},
+	})
+	want := map[string]string{"team": "db", "app": "orders"}
+	if got := clusters[0].UserIntent.GetInstanceTags(); !reflect.DeepEqual(got, want) {
+		t.Errorf("PRIMARY: got %v, want %v", got, want)
+	}
+	if clusters[1].UserIntent.InstanceTags != nil {
+		t.Errorf("ASYNC: got %v, want no tags", clusters[1].UserIntent.GetInstanceTags())
+	}
+}
+
+func TestStripDefaultTags(t *testing.T) {
+	newCluster := func(uuid string, tags map[string]string) map[string]interface{} {
+		return map[string]interface{}{
+			"uuid": uuid,
+			"user_intent": []interface{}{map[string]interface{}{
+				"instance_tags": tags,
+			}},
+		}
+	}
+	oldCluster := func(uuid string, tags map[string]interface{}) interface{} {
+		return map[string]interface{}{
+			"uuid": uuid,
+			"user_intent": []interface{}{map[string]interface{}{
+				"instance_tags": tags,
+			}},
+		}
+	}
+	defaults := map[string]string{"team": "db", "env": "prod"}
+	newClusters := []map[string]interface{}{
+		// team comes from the defaults; env is configured with the default value.
+		newCluster("p", map[string]string{"team": "db", "env": "prod", "app": "orders"}),
+		// A tag that differs from its default was set outside of the defaults.
+		newCluster("r", map[string]string{"team": "ops"}),
+	}
+	oldClusters := []interface{}{
+		oldCluster("r", nil),
+		oldCluster("p", map[string]interface{}{"env": "prod", "app": "orders"}),
+	}
+	stripDefaultTags(newClusters, oldClusters, defaults)
+
+	tags := func(cl map[string]interface{}) map[string]string {
+		ui := utils.MapFromSingletonList(cl["user_intent"].([]interface{}))
+		return ui["instance_tags"].(map[string]string)
+	}
+	if got, want := tags(newClusters[0]),
+		(map[string]string{"env": "prod", "app": "orders"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("PRIMARY: got %v, want %v", got, want)
+	}
+	if got, want := tags(newClusters[1]),
+		(map[string]string{"team": "ops"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("ASYNC: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,357 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package universe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/provider/providerutil"
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+// Values of the service_scope attribute. YBA stores the scope as the
+// defaultServiceScopeAZ flag of the user intent.
+const (
+	serviceScopeAZ         = "AZ"
+	serviceScopeNamespaced = "Namespaced"
+)
+
+// kubernetesSchema is the kubernetes block of a cluster's user_intent.
+func kubernetesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		MaxItems: 1,
+		Optional: true,
+		Computed: true,
+		Description: "Settings of a universe on a Kubernetes provider (see " +
+			"`yba_kubernetes_provider`). Invalid for other providers. All inner " +
+			"fields are Optional+Computed: omitting one in HCL preserves the " +
+			"existing value. Override changes dispatch the YugabyteDB Anywhere " +
+			"Kubernetes overrides upgrade, which restarts the pods in a rolling " +
+			"fashion; resource changes are applied as a cluster edit.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"universe_overrides": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Computed: true,
+					Description: "Helm chart overrides (YAML) applied to every pod of " +
+						"the universe. Only read from the PRIMARY cluster.",
+				},
+				"az_overrides": {
+					Type:     schema.TypeMap,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+					Optional: true,
+					Computed: true,
+					Description: "Helm chart overrides (YAML) per availability zone, " +
+						"keyed by zone code, on top of universe_overrides. Only read " +
+						"from the PRIMARY cluster.",
+				},
+				"tserver_resources": kubernetesResourceSpecSchema(
+					"Resources requested by each TServer pod."),
+				"master_resources": kubernetesResourceSpecSchema(
+					"Resources requested by each Master pod. Only valid on the PRIMARY cluster."),
+				"expose_service": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Computed: true,
+					ValidateFunc: validation.StringInSlice(
+						[]string{"NONE", "EXPOSED", "UNEXPOSED"}, false),
+					Description: "Whether the universe services get an external load " +
+						"balancer. Allowed values: NONE, EXPOSED, UNEXPOSED. Cannot be " +
+						"changed after the universe is created.",
+				},
+				"service_scope": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Computed: true,
+					ValidateFunc: validation.StringInSlice(
+						[]string{serviceScopeAZ, serviceScopeNamespaced}, false),
+					Description: "Scope of the universe services: AZ creates services " +
+						"per availability zone, Namespaced shares them across the zones " +
+						"of a namespace. Allowed values: AZ, Namespaced. Cannot be " +
+						"changed after the universe is created.",
+				},
+			},
+		},
+	}
+}
+
+// kubernetesResourceSpecSchema is the CPU and memory request of the pods of
+// one server process.
+func kubernetesResourceSpecSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		Optional:    true,
+		Computed:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"cpu": {
+					Type:         schema.TypeFloat,
+					Required:     true,
+					ValidateFunc: validation.FloatAtLeast(0.1),
+					Description:  "CPU cores requested by each pod.",
+				},
+				"memory_gib": {
+					Type:         schema.TypeFloat,
+					Required:     true,
+					ValidateFunc: validation.FloatAtLeast(0.1),
+					Description:  "Memory in GiB requested by each pod.",
+				},
+			},
+		},
+	}
+}
+
+// buildKubernetesIntent copies the kubernetes block of a user_intent onto
+// intent. Without the block the Kubernetes fields stay nil, so YBA keeps what
+// it has.
+func buildKubernetesIntent(intent *client.UserIntent, ui map[string]interface{}) {
+	k8s := utils.MapFromSingletonList(kubernetesList(ui))
+	if len(k8s) == 0 {
+		return
+	}
+	intent.UniverseOverrides = utils.GetStringPointer(k8s["universe_overrides"].(string))
+	intent.AzOverrides = utils.StringMap(k8s["az_overrides"].(map[string]interface{}))
+	intent.TserverK8SNodeResourceSpec = buildK8SNodeResourceSpec(k8s["tserver_resources"])
+	intent.MasterK8SNodeResourceSpec = buildK8SNodeResourceSpec(k8s["master_resources"])
+	if v := k8s["expose_service"].(string); v != "" {
+		intent.EnableExposingService = utils.GetStringPointer(v)
+	}
+	if v := k8s["service_scope"].(string); v != "" {
+		intent.DefaultServiceScopeAZ = utils.GetBoolPointer(v == serviceScopeAZ)
+	}
+}
+
+// kubernetesList returns the kubernetes block of a user_intent map. The read
+// replica resource shares the user_intent builder, so the key may be absent.
+func kubernetesList(ui map[string]interface{}) []interface{} {
+	l, _ := ui["kubernetes"].([]interface{})
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	return l
+}
+
+func buildK8SNodeResourceSpec(v interface{}) *client.K8SNodeResourceSpec {
+	spec := utils.MapFromSingletonList(v.([]interface{}))
+	if len(spec) == 0 {
+		return nil
+	}
+	return &client.K8SNodeResourceSpec{
+		CpuCoreCount: utils.GetFloat64Pointer(spec["cpu"].(float64)),
+		MemoryGib:    utils.GetFloat64Pointer(spec["memory_gib"].(float64)),
+	}
+}
+
+// flattenKubernetes converts the Kubernetes fields of a user intent into the
+// kubernetes block. It is empty for universes on other providers.
+func flattenKubernetes(ui client.UserIntent) []interface{} {
+	if ui.GetProviderType() != providerutil.K8sProviderCode {
+		return []interface{}{}
+	}
+	scope := serviceScopeNamespaced
+	if ui.GetDefaultServiceScopeAZ() {
+		scope = serviceScopeAZ
+	}
+	return utils.CreateSingletonList(map[string]interface{}{
+		"universe_overrides": ui.GetUniverseOverrides(),
+		"az_overrides":       ui.GetAzOverrides(),
+		"tserver_resources":  flattenK8SNodeResourceSpec(ui.TserverK8SNodeResourceSpec),
+		"master_resources":   flattenK8SNodeResourceSpec(ui.MasterK8SNodeResourceSpec),
+		"expose_service":     ui.GetEnableExposingService(),
+		"service_scope":      scope,
+	})
+}
+
+func flattenK8SNodeResourceSpec(spec *client.K8SNodeResourceSpec) []interface{} {
+	if spec == nil {
+		return []interface{}{}
+	}
+	return utils.CreateSingletonList(map[string]interface{}{
+		"cpu":        spec.GetCpuCoreCount(),
+		"memory_gib": spec.GetMemoryGib(),
+	})
+}
+
+// hasKubernetesIntent reports whether a built user intent carries any field of
+// the kubernetes block.
+func hasKubernetesIntent(ui client.UserIntent) bool {
+	return ui.UniverseOverrides != nil || ui.AzOverrides != nil ||
+		ui.TserverK8SNodeResourceSpec != nil || ui.MasterK8SNodeResourceSpec != nil ||
+		ui.EnableExposingService != nil || ui.DefaultServiceScopeAZ != nil
+}
+
+// isKubernetesUniverse reports whether the PRIMARY cluster runs on a
+// Kubernetes provider.
+func isKubernetesUniverse(clusters []client.Cluster) bool {
+	primary, ok := getClusterByType(clusters, "PRIMARY")
+	return ok && primary.UserIntent.GetProviderType() == providerutil.K8sProviderCode
+}
+
+// useNewHelmNaming opts a new Kubernetes universe into the Helm release naming
+// YBA uses for universes created from its UI. The legacy naming is only kept
+// for universes that predate it.
+func useNewHelmNaming(clusters []client.Cluster) {
+	if !isKubernetesUniverse(clusters) {
+		return
+	}
+	for i := range clusters {
+		clusters[i].UserIntent.UseNewHelmNamingStyle = utils.GetBoolPointer(true)
+	}
+}
+
+// kubernetesOverridesChanged reports whether newUI asks for other Helm
+// overrides than oldUI has. A nil field in newUI means the kubernetes block is
+// absent and leaves the overrides alone.
+func kubernetesOverridesChanged(oldUI, newUI client.UserIntent) bool {
+	if newUI.UniverseOverrides != nil &&
+		oldUI.GetUniverseOverrides() != newUI.GetUniverseOverrides() {
+		return true
+	}
+	return newUI.AzOverrides != nil && !maps.Equal(oldUI.GetAzOverrides(), newUI.GetAzOverrides())
+}
+
+// kubernetesResourcesChanged reports whether newUI asks for other pod
+// resources than oldUI has. Like the overrides, a nil spec leaves it alone.
+func kubernetesResourcesChanged(oldUI, newUI client.UserIntent) bool {
+	return k8sNodeResourceSpecChanged(oldUI.TserverK8SNodeResourceSpec,
+		newUI.TserverK8SNodeResourceSpec) ||
+		k8sNodeResourceSpecChanged(oldUI.MasterK8SNodeResourceSpec,
+			newUI.MasterK8SNodeResourceSpec)
+}
+
+func k8sNodeResourceSpecChanged(old, new *client.K8SNodeResourceSpec) bool {
+	if new == nil {
+		return false
+	}
+	return old == nil || old.GetCpuCoreCount() != new.GetCpuCoreCount() ||
+		old.GetMemoryGib() != new.GetMemoryGib()
+}
+
+// validateKubernetesClusters runs the plan-time checks of the kubernetes
+// blocks that need no provider lookup. The provider-dependent checks run with
+// the other per-cluster provider checks in resourceUniverseDiff.
+func validateKubernetesClusters(
+	ctx context.Context,
+	d *schema.ResourceDiff,
+	meta interface{},
+) error {
+	oldRaw, newRaw := d.GetChange("clusters")
+	newClusters := buildClusters(newRaw.([]interface{}))
+	for _, cl := range newClusters {
+		if cl.GetClusterType() == "ASYNC" && cl.UserIntent.MasterK8SNodeResourceSpec != nil {
+			return errors.New("kubernetes.master_resources is invalid on a Read Replica " +
+				"(ASYNC) cluster: ASYNC clusters have no master processes")
+		}
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	oldClusters := buildClusters(oldRaw.([]interface{}))
+	for i, newCl := range newClusters {
+		if i >= len(oldClusters) {
+			break
+		}
+		oldUI, newUI := oldClusters[i].UserIntent, newCl.UserIntent
+		if oldUI.EnableExposingService != nil && newUI.EnableExposingService != nil &&
+			oldUI.GetEnableExposingService() != newUI.GetEnableExposingService() {
+			return errors.New("kubernetes.expose_service cannot be changed after the " +
+				"universe is created")
+		}
+		if oldUI.DefaultServiceScopeAZ != nil && newUI.DefaultServiceScopeAZ != nil &&
+			oldUI.GetDefaultServiceScopeAZ() != newUI.GetDefaultServiceScopeAZ() {
+			return errors.New("kubernetes.service_scope cannot be changed after the " +
+				"universe is created")
+		}
+	}
+	return nil
+}
+
+// validateKubernetesProvider checks the kubernetes block and dedicated_masters
+// of one cluster against the code of its provider.
+func validateKubernetesProvider(ui client.UserIntent, code, label string) error {
+	if code != providerutil.K8sProviderCode {
+		if hasKubernetesIntent(ui) {
+			return fmt.Errorf("the kubernetes block is only valid for Kubernetes "+
+				"providers in the %s cluster", label)
+		}
+		return nil
+	}
+	if ui.GetDedicatedNodes() {
+		return fmt.Errorf("dedicated_masters is not applicable to Kubernetes providers "+
+			"in the %s cluster: masters always run in their own pods", label)
+	}
+	return nil
+}
+
+// performKubernetesOverridesUpgrade dispatches the Kubernetes overrides
+// upgrade with the overrides of newUI, the PRIMARY cluster's intent.
+func performKubernetesOverridesUpgrade(
+	ctx context.Context,
+	c *client.APIClient,
+	cUUID string,
+	d *schema.ResourceData,
+	updateUni *client.UniverseResp,
+	primaryIdx int,
+	newUI client.UserIntent,
+	sleepAfterMasterMs, sleepAfterTServerMs int32,
+	abortOnCancel bool,
+) diag.Diagnostics {
+	// Live-intent stamp, as for the other upgrades. A nil field leaves the
+	// current overrides in place.
+	live := &updateUni.UniverseDetails.Clusters[primaryIdx].UserIntent
+	if newUI.UniverseOverrides != nil {
+		live.UniverseOverrides = newUI.UniverseOverrides
+	}
+	if newUI.AzOverrides != nil {
+		live.AzOverrides = newUI.AzOverrides
+	}
+
+	// YBA restarts the pods one at a time for an overrides upgrade, whatever
+	// node_restart_settings.upgrade_option says.
+	req := client.KubernetesOverridesUpgradeParams{
+		Clusters:                       updateUni.UniverseDetails.Clusters,
+		UpgradeOption:                  "Rolling",
+		SleepAfterMasterRestartMillis:  sleepAfterMasterMs,
+		SleepAfterTServerRestartMillis: sleepAfterTServerMs,
+	}
+	req.SetUniverseOverrides(live.GetUniverseOverrides())
+	req.SetAzOverrides(live.GetAzOverrides())
+	return utils.DispatchAndWait(ctx, "Kubernetes Overrides Upgrade", cUUID, c,
+		d.Timeout(schema.TimeoutUpdate), abortOnCancel,
+		utils.ResourceEntity, "Universe", "Update - Kubernetes Overrides",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.UniverseUpgradesManagementAPI.UpgradeKubernetesOverrides(
+				ctx, cUUID, d.Id()).KubernetesOverridesUpgradeParams(req).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			return r.GetTaskUUID(), resp, nil
+		},
+	)
+}
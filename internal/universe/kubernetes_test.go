@@ -0,0 +1,149 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package universe
+
+import (
+	"reflect"
+	"testing"
+
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+func TestBuildKubernetesIntent(t *testing.T) {
+	ui := map[string]interface{}{
+		"kubernetes": []interface{}{map[string]interface{}{
+			"universe_overrides": "tserver:\n  podLabels:\n    team: db\n",
+			"az_overrides":       map[string]interface{}{"us-west1-a": "master: {}\n"},
+			"tserver_resources": []interface{}{map[string]interface{}{
+				"cpu": 2.0, "memory_gib": 4.0,
+			}},
+			"master_resources": []interface{}{},
+			"expose_service":   "UNEXPOSED",
+			"service_scope":    "Namespaced",
+		}},
+	}
+	var intent client.UserIntent
+	buildKubernetesIntent(&intent, ui)
+
+	if got := intent.GetUniverseOverrides(); got != "tserver:\n  podLabels:\n    team: db\n" {
+		t.Errorf("universe overrides = %q", got)
+	}
+	if got := intent.GetAzOverrides(); !reflect.DeepEqual(got,
+		map[string]string{"us-west1-a": "master: {}\n"}) {
+		t.Errorf("az overrides = %v", got)
+	}
+	spec := intent.TserverK8SNodeResourceSpec
+	if spec == nil || spec.GetCpuCoreCount() != 2 || spec.GetMemoryGib() != 4 {
+		t.Errorf("tserver resources = %+v", spec)
+	}
+	if intent.MasterK8SNodeResourceSpec != nil {
+		t.Errorf("master resources = %+v, want nil", intent.MasterK8SNodeResourceSpec)
+	}
+	if intent.GetEnableExposingService() != "UNEXPOSED" {
+		t.Errorf("expose service = %q", intent.GetEnableExposingService())
+	}
+	if intent.DefaultServiceScopeAZ == nil || intent.GetDefaultServiceScopeAZ() {
+		t.Errorf("default service scope AZ = %v, want false", intent.DefaultServiceScopeAZ)
+	}
+
+	// Without the block, as in the read replica resource, nothing is set.
+	var empty client.UserIntent
+	buildKubernetesIntent(&empty, map[string]interface{}{})
+	if hasKubernetesIntent(empty) {
+		t.Errorf("intent without kubernetes block = %+v", empty)
+	}
+}
+
+func TestFlattenKubernetes(t *testing.T) {
+	ui := client.UserIntent{
+		ProviderType:      utils.GetStringPointer("kubernetes"),
+		UniverseOverrides: utils.GetStringPointer("master: {}\n"),
+		MasterK8SNodeResourceSpec: &client.K8SNodeResourceSpec{
+			CpuCoreCount: utils.GetFloat64Pointer(1),
+			MemoryGib:    utils.GetFloat64Pointer(2),
+		},
+		EnableExposingService: utils.GetStringPointer("EXPOSED"),
+		DefaultServiceScopeAZ: utils.GetBoolPointer(true),
+	}
+	got := utils.MapFromSingletonList(flattenKubernetes(ui))
+	if got["universe_overrides"] != "master: {}\n" {
+		t.Errorf("universe_overrides = %v", got["universe_overrides"])
+	}
+	if got["service_scope"] != serviceScopeAZ || got["expose_service"] != "EXPOSED" {
+		t.Errorf("service settings = %v, %v", got["service_scope"], got["expose_service"])
+	}
+	if l := got["tserver_resources"].([]interface{}); len(l) != 0 {
+		t.Errorf("tserver_resources = %v, want empty", l)
+	}
+	master := utils.MapFromSingletonList(got["master_resources"].([]interface{}))
+	if master["cpu"] != 1.0 || master["memory_gib"] != 2.0 {
+		t.Errorf("master_resources = %v", master)
+	}
+
+	ui.ProviderType = utils.GetStringPointer("aws")
+	if l := flattenKubernetes(ui); len(l) != 0 {
+		t.Errorf("flatten on aws = %v, want empty", l)
+	}
+}
+
+func TestKubernetesChanges(t *testing.T) {
+	spec := func(cpu, mem float64) *client.K8SNodeResourceSpec {
+		return &client.K8SNodeResourceSpec{
+			CpuCoreCount: utils.GetFloat64Pointer(cpu),
+			MemoryGib:    utils.GetFloat64Pointer(mem),
+		}
+	}
+	old := client.UserIntent{
+		UniverseOverrides:          utils.GetStringPointer("a"),
+		AzOverrides:                &map[string]string{"az1": "b"},
+		TserverK8SNodeResourceSpec: spec(2, 4),
+	}
+
+	if kubernetesOverridesChanged(old, client.UserIntent{}) ||
+		kubernetesResourcesChanged(old, client.UserIntent{}) {
+		t.Error("an absent kubernetes block must not be a change")
+	}
+	if !kubernetesOverridesChanged(old, client.UserIntent{
+		AzOverrides: &map[string]string{"az1": "c"},
+	}) {
+		t.Error("az_overrides change not detected")
+	}
+	if kubernetesResourcesChanged(old, client.UserIntent{TserverK8SNodeResourceSpec: spec(2, 4)}) {
+		t.Error("equal tserver resources reported as changed")
+	}
+	if !kubernetesResourcesChanged(old, client.UserIntent{MasterK8SNodeResourceSpec: spec(1, 1)}) {
+		t.Error("new master resources not detected")
+	}
+}
+
+func TestValidateKubernetesProvider(t *testing.T) {
+	k8s := client.UserIntent{UniverseOverrides: utils.GetStringPointer("")}
+	if err := validateKubernetesProvider(k8s, "aws", "primary"); err == nil {
+		t.Error("kubernetes block on aws: want error")
+	}
+	if err := validateKubernetesProvider(k8s, "kubernetes", "primary"); err != nil {
+		t.Errorf("kubernetes block on kubernetes: %v", err)
+	}
+	dedicated := client.UserIntent{DedicatedNodes: utils.GetBoolPointer(true)}
+	if err := validateKubernetesProvider(dedicated, "kubernetes", "primary"); err == nil {
+		t.Error("dedicated_masters on kubernetes: want error")
+	}
+	if err := validateKubernetesProvider(dedicated, "gcp", "primary"); err != nil {
+		t.Errorf("dedicated_masters on gcp: %v", err)
+	}
+}
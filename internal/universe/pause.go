@@ -0,0 +1,119 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package universe
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+// pausedUniverseSettings are the attributes that may change while a universe
+// stays paused: provider-side settings that dispatch nothing to YBA until a
+// later edit reads them, and universe_key_history, which only a refresh sets.
+//
+// The other computed attributes stay blocked, as they only change with an
+// edit: instance_tags_all and node_instance_tags with a tag change (including
+// one of the provider's default_tags), which needs a cluster edit, and
+// ysql_major_upgrade_precheck with a software upgrade. password_rotation_trigger
+// dispatches a password rotation, which needs running nodes.
+var pausedUniverseSettings = []string{
+	"paused",
+	"external_read_replica",
+	"delete_options",
+	"task_recovery",
+	"full_move",
+	"node_restart_settings",
+	"failed_task_uuid",
+	"universe_key_history",
+	utils.PendingTaskUUIDKey,
+}
+
+// checkPausedEdits rejects an update that changes a universe which is paused
+// and stays paused. YBA stops the nodes of a paused universe, so any edit,
+// upgrade or rotation dispatched to it fails part way.
+func checkPausedEdits(d *schema.ResourceData) diag.Diagnostics {
+	oldPaused, newPaused := d.GetChange("paused")
+	if !oldPaused.(bool) || !newPaused.(bool) {
+		return nil
+	}
+	if !d.HasChangesExcept(pausedUniverseSettings...) {
+		return nil
+	}
+	var changed []string
+	for k := range d.State().Attributes {
+		name, _, _ := strings.Cut(k, ".")
+		if slices.Contains(changed, name) || slices.Contains(pausedUniverseSettings, name) {
+			continue
+		}
+		if d.HasChange(name) {
+			changed = append(changed, name)
+		}
+	}
+	if len(changed) == 0 {
+		// Only removed attributes changed; they are not in the new state.
+		changed = []string{"its configuration"}
+	}
+	sort.Strings(changed)
+	return diag.Errorf("Universe %s is paused and cannot apply changes to %s. Set "+
+		"paused = false to resume the universe in the same apply, or apply the changes "+
+		"after it has been resumed.", d.Id(), strings.Join(changed, ", "))
+}
+
+// setUniversePaused pauses or resumes the universe and waits for the task.
+func setUniversePaused(
+	ctx context.Context,
+	d *schema.ResourceData,
+	c *client.APIClient,
+	cUUID string,
+	timeout time.Duration,
+	abortOnCancel bool,
+	paused bool,
+) diag.Diagnostics {
+	if paused {
+		return utils.DispatchAndWait(ctx, "Pause Universe", cUUID, c, timeout, abortOnCancel,
+			utils.ResourceEntity, "Universe", "Pause",
+			func() (string, *http.Response, error) {
+				r, resp, err := c.UniverseManagementAPI.PauseUniverse(ctx, cUUID, d.Id()).
+					Execute()
+				if err != nil {
+					return "", resp, err
+				}
+				return r.GetTaskUUID(), resp, nil
+			},
+		)
+	}
+	return utils.DispatchAndWait(ctx, "Resume Universe", cUUID, c, timeout, abortOnCancel,
+		utils.ResourceEntity, "Universe", "Resume",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.UniverseManagementAPI.ResumeUniverse(ctx, cUUID, d.Id()).
+				Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			return r.GetTaskUUID(), resp, nil
+		},
+	)
+}
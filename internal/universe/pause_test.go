@@ -0,0 +1,121 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package universe
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testUniverseUpdateData builds the ResourceData of an update from a universe
+// created with oldConfig to newConfig. writeOnly, when set, holds the
+// write-only arguments of the new raw config. CustomizeDiff is not run.
+func testUniverseUpdateData(
+	t *testing.T, oldConfig, newConfig map[string]interface{}, writeOnly map[string]cty.Value,
+) *schema.ResourceData {
+	t.Helper()
+	sm := schema.InternalMap(ResourceUniverse().Schema)
+	ctx := context.Background()
+	created, err := sm.Diff(ctx, nil, terraform.NewResourceConfigRaw(oldConfig), nil, nil,
+		true)
+	if err != nil {
+		t.Fatalf("create diff: %v", err)
+	}
+	d, err := sm.Data(nil, created)
+	if err != nil {
+		t.Fatalf("create data: %v", err)
+	}
+	d.SetId("u1")
+	state := d.State()
+
+	updated, err := sm.Diff(ctx, state, terraform.NewResourceConfigRaw(newConfig), nil, nil,
+		true)
+	if err != nil {
+		t.Fatalf("update diff: %v", err)
+	}
+	if writeOnly != nil {
+		updated.RawConfig = cty.ObjectVal(writeOnly)
+	}
+	d, err = sm.Data(state, updated)
+	if err != nil {
+		t.Fatalf("update data: %v", err)
+	}
+	return d
+}
+
+func TestCheckPausedEdits(t *testing.T) {
+	paused := map[string]interface{}{"paused": true, "root_ca": "ca-1"}
+	tests := []struct {
+		name      string
+		oldConfig map[string]interface{}
+		newConfig map[string]interface{}
+		wantError string
+	}{
+		{
+			name:      "edit while paused",
+			oldConfig: paused,
+			newConfig: map[string]interface{}{"paused": true, "root_ca": "ca-2"},
+			wantError: "cannot apply changes to root_ca",
+		},
+		{
+			name:      "settings while paused",
+			oldConfig: paused,
+			newConfig: map[string]interface{}{
+				"paused":  true,
+				"root_ca": "ca-1",
+				"delete_options": []interface{}{map[string]interface{}{
+					"force_delete": true,
+				}},
+				"node_restart_settings": []interface{}{map[string]interface{}{
+					"upgrade_option": "Non-Rolling",
+				}},
+			},
+		},
+		{
+			name:      "edit while resuming",
+			oldConfig: paused,
+			newConfig: map[string]interface{}{"paused": false, "root_ca": "ca-2"},
+		},
+		{
+			name:      "edit while running",
+			oldConfig: map[string]interface{}{"root_ca": "ca-1"},
+			newConfig: map[string]interface{}{"root_ca": "ca-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testUniverseUpdateData(t, tt.oldConfig, tt.newConfig, nil)
+			diags := checkPausedEdits(d)
+			if tt.wantError == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+				return
+			}
+			if !diags.HasError() {
+				t.Fatalf("expected an error containing %q", tt.wantError)
+			}
+			if got := diags[0].Summary; !strings.Contains(got, tt.wantError) {
+				t.Errorf("error %q does not contain %q", got, tt.wantError)
+			}
+		})
+	}
+}
@@ -521,51 +521,12 @@ func ResourceUniverse() *schema.Resource {
 					},
 				},
 			},
-			"node_restart_settings": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
-				Description: "Controls how node restarts are performed during upgrade operations " +
+			"node_restart_settings": nodeRestartSettingsSchema(
+				"Controls how node restarts are performed during upgrade operations " +
 					"(DB version, GFlags, Systemd, Finalize, Rollback, certificate rotation). " +
 					"When omitted, " +
 					"YugabyteDB Anywhere platform defaults apply: Rolling strategy with " +
-					"180000 ms (3 minutes) sleep after each master and TServer restart.",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"upgrade_option": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "Rolling",
-							ValidateDiagFunc: validation.ToDiagFunc(
-								validation.StringInSlice(
-									[]string{"Rolling", "Non-Rolling", "Non-Restart"}, false)),
-							Description: "Node restart strategy applied to all upgrade operations. " +
-								"Allowed values: Rolling, Non-Rolling, Non-Restart. Defaults to " +
-								"Rolling (YugabyteDB Anywhere platform default). TLS toggle always " +
-								"uses Non-Rolling; ResizeNode and VMImageUpgrade always use Rolling, " +
-								"regardless of this setting.",
-						},
-						"sleep_after_master_restart_millis": {
-							Type:             schema.TypeInt,
-							Optional:         true,
-							Default:          180000,
-							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
-							Description: "Milliseconds to sleep after each master node restart. " +
-								"Must be 0 or a positive integer. Defaults to 180000 (3 minutes), " +
-								"matching the YugabyteDB Anywhere platform default.",
-						},
-						"sleep_after_tserver_restart_millis": {
-							Type:             schema.TypeInt,
-							Optional:         true,
-							Default:          180000,
-							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
-							Description: "Milliseconds to sleep after each TServer node restart. " +
-								"Must be 0 or a positive integer. Defaults to 180000 (3 minutes), " +
-								"matching the YugabyteDB Anywhere platform default.",
-						},
-					},
-				},
-			},
+					"180000 ms (3 minutes) sleep after each master and TServer restart."),
 			"db_version_upgrade_state": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -573,6 +534,33 @@ func ResourceUniverse() *schema.Resource {
 					"Possible values: Ready, Upgrading, UpgradeFailed, PreFinalize, Finalizing, " +
 					"FinalizeFailed, RollingBack, RollbackFailed.",
 			},
+			"ysql_major_upgrade_precheck": ysqlMajorUpgradePrecheckSchema(),
+			"password_rotation_trigger":   passwordRotationTriggerSchema(),
+			"ysql_password_wo":            writeOnlyPasswordSchema(ysqlPassword),
+			"ycql_password_wo":            writeOnlyPasswordSchema(ycqlPassword),
+			"ysql_current_password_wo":    currentPasswordSchema(ysqlPassword),
+			"ycql_current_password_wo":    currentPasswordSchema(ycqlPassword),
+			"external_read_replica":       externalReadReplicaSchema(),
+			"instance_tags_all":           instanceTagsAllSchema(),
+			"node_instance_tags":          nodeInstanceTagsSchema(),
+			"paused": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Pause the universe: YugabyteDB Anywhere stops its nodes and, on " +
+					"cloud providers, releases their compute. Set back to false to resume it. " +
+					"Read reports the state YugabyteDB Anywhere holds, so a universe paused " +
+					"outside Terraform shows as a change back to false. Other changes to a " +
+					"universe that stays paused are rejected at apply; resuming in the same " +
+					"apply applies them after the universe is running, and pausing in the " +
+					"same apply applies them before it is paused. False by default.",
+			},
+			"encryption_at_rest":     encryptionAtRestSchema(),
+			"active_kms_config_uuid": activeKMSConfigUUIDSchema(),
+			"universe_key_history":   universeKeyHistorySchema(),
+			"task_recovery":          taskRecoverySchema(),
+			"failed_task_uuid":       failedTaskUUIDSchema(),
+			utils.PendingTaskUUIDKey: utils.PendingTaskUUIDSchema(),
 		},
 	}
 }
@@ -662,6 +650,7 @@ func getClusterByType(clusters []client.Cluster, clusterType string) (client.Clu
 
 func resourceUniverseDiff() schema.CustomizeDiffFunc {
 	return customdiff.All(
+		validateExternalReadReplica,
 		customdiff.ValidateValue("clusters", func(ctx context.Context, value,
 			meta interface{}) error {
 			// Exactly one PRIMARY cluster and at most one ASYNC cluster are allowed.
@@ -2011,14 +2000,6 @@ func resourceUniverseDiff() schema.CustomizeDiffFunc {
 					return errors.New(
 						"use_systemd must be true: non-systemd universes are not supported")
 				}
-				if ui.GetEnableYSQLAuth() && ui.GetYsqlPassword() == "" {
-					return errors.New(
-						"ysql_password is required when enable_ysql_auth is true")
-				}
-				if ui.GetEnableYCQLAuth() && ui.GetYcqlPassword() == "" {
-					return errors.New(
-						"ycql_password is required when enable_ycql_auth is true")
-				}
 				return nil
 			},
 		),
@@ -2028,7 +2009,9 @@ func resourceUniverseDiff() schema.CustomizeDiffFunc {
 			// cluster to resolve the provider code and run all checks together:
 			//   1. access_key_code is required for cloud providers (aws, gcp, azu).
 			//   2. image_bundle_uuid is not applicable for on-prem providers.
-			//   3. When image_bundle_uuid is omitted for a cloud provider, the
+			//   3. An explicit image_bundle_uuid must match the universe arch.
+			//   4. The kubernetes block is only valid for Kubernetes providers.
+			//   5. When image_bundle_uuid is omitted for a cloud provider, the
 			//      provider must have a default image bundle for the configured arch
 			//      so the YBA API auto-resolution will succeed.
 			// API errors are silenced and deferred to the real create/update call
@@ -2099,7 +2082,13 @@ func resourceUniverseDiff() schema.CustomizeDiffFunc {
 					}
 				}
 
-				// Check 4: cloud provider with no image_bundle_uuid must have a
+				// Check 4: the kubernetes block needs a Kubernetes provider, and
+				// dedicated_masters does not apply to one.
+				if err := validateKubernetesProvider(ui, code, label); err != nil {
+					return err
+				}
+
+				// Check 5: cloud provider with no image_bundle_uuid must have a
 				// default bundle for the configured arch so API auto-resolution works.
 				if cloudProviders[code] && ui.GetImageBundleUUID() == "" {
 					for _, b := range p.GetImageBundles() {
@@ -2130,6 +2119,15 @@ func resourceUniverseDiff() schema.CustomizeDiffFunc {
 			}
 			return nil
 		},
+		validateKubernetesClusters,
+		validateYsqlMajorUpgrade,
+		validateDBPasswords,
+		planInstanceTagsAll,
+		customdiff.ValidateValue("clusters",
+			func(ctx context.Context, value, meta interface{}) error {
+				return validateConnectionPooling(value.([]interface{}))
+			},
+		),
 		// Validate cloud_list for duplicate region/AZ codes at plan time.
 		// Duplicate codes produce the cryptic BE error:
 		// "Duplicate key <uuid> (attempted merging values N and M)".
@@ -2630,43 +2628,6 @@ func resourceUniverseDiff() schema.CustomizeDiffFunc {
 				return nil
 			},
 		),
-
-		// YBA ConfigureYSQL/ConfigureYCQL APIs: ysql_password, ycql_password.
-		// The provider does not call those APIs, so a password change in config
-		// would be written to state without affecting the live universe.
-		// Remove this block when password-change update is implemented.
-		customdiff.ValidateChange("clusters",
-			func(ctx context.Context, old, new, m interface{}) error {
-				if len(old.([]interface{})) == 0 {
-					return nil
-				}
-				oldClusters := buildClusters(old.([]interface{}))
-				newClusters := buildClusters(new.([]interface{}))
-				for i, oldCl := range oldClusters {
-					if i >= len(newClusters) {
-						continue
-					}
-					newCl := newClusters[i]
-					oldYSQL := oldCl.UserIntent.GetYsqlPassword()
-					newYSQL := newCl.UserIntent.GetYsqlPassword()
-					if oldYSQL != "" && newYSQL != oldYSQL {
-						return errors.New(
-							"ysql_password cannot be changed after universe creation: " +
-								"password update support is not yet implemented in " +
-								"this provider version")
-					}
-					oldYCQL := oldCl.UserIntent.GetYcqlPassword()
-					newYCQL := newCl.UserIntent.GetYcqlPassword()
-					if oldYCQL != "" && newYCQL != oldYCQL {
-						return errors.New(
-							"ycql_password cannot be changed after universe creation: " +
-								"password update support is not yet implemented in " +
-								"this provider version")
-					}
-				}
-				return nil
-			},
-		),
 		// --- END PENDING UPDATE SUPPORT ---
 		// Plan-time placement validator. Mirrors YBA's
 		// PlacementInfoUtil.checkReplicasDistributionIsCorrect so an invalid
@@ -2824,6 +2785,7 @@ func resourceUniverseDiff() schema.CustomizeDiffFunc {
 			return nil
 		},
 		// --- END PENDING UPDATE SUPPORT ---
+		planTaskRecovery,
 	)
 }
 
@@ -2991,6 +2953,7 @@ func resourceUniverseCreate(
 	meta interface{}) diag.Diagnostics {
 	c := meta.(*api.APIClient).YugawareClient
 	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
 
 	if err := resolveProviderTypes(ctx, c, cUUID, d); err != nil {
 		return diag.FromErr(err)
@@ -2998,20 +2961,58 @@ func resourceUniverseCreate(
 	if err := resolveCloudListUUIDs(ctx, c, cUUID, d); err != nil {
 		return diag.FromErr(err)
 	}
-	req := buildUniverse(d)
-	r, response, err := c.UniverseClusterMutationsAPI.CreateAllClusters(ctx, cUUID).
-		UniverseConfigureTaskParams(req).Execute()
-	if err != nil {
-		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
-			"Universe", "Create")
-		return diag.FromErr(errMessage)
+	if err := resolveInstanceTagsAll(ctx, d, meta); err != nil {
+		return diag.FromErr(err)
 	}
-	d.SetId(*r.ResourceUUID)
-	tflog.Debug(ctx, fmt.Sprintf("Waiting for universe %s to be active", d.Id()))
-	if err = utils.WaitForTask(ctx, r.GetTaskUUID(), cUUID, c,
-		d.Timeout(schema.TimeoutCreate)); err != nil {
+	req := buildUniverse(d)
+	useNewHelmNaming(req.Clusters)
+	if err := applyWriteOnlyPasswords(d, req.Clusters); err != nil {
 		return diag.FromErr(err)
 	}
+	// Connection pooling enabled at creation needs no restart; its flags are
+	// applied by performConnectionPooling once the universe exists.
+	if want, ok := configuredConnectionPooling(d); ok && want.enabled {
+		for i := range req.Clusters {
+			if req.Clusters[i].GetClusterType() == "PRIMARY" {
+				req.Clusters[i].UserIntent.SetEnableConnectionPooling(true)
+			}
+		}
+	}
+	// The ID is set before waiting so an interrupted create stays in state
+	// with its pending task, instead of being created a second time.
+	ctx = utils.TrackPendingTask(ctx, d)
+	if diags := utils.DispatchAndWait(ctx, "Create Universe", cUUID, c,
+		d.Timeout(schema.TimeoutCreate), abortOnCancel,
+		utils.ResourceEntity, "Universe", "Create",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.UniverseClusterMutationsAPI.CreateAllClusters(ctx, cUUID).
+				UniverseConfigureTaskParams(req).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			d.SetId(r.GetResourceUUID())
+			tflog.Debug(ctx, fmt.Sprintf("Waiting for universe %s to be active", d.Id()))
+			return r.GetTaskUUID(), resp, nil
+		},
+	); diags != nil {
+		return diags
+	}
+	if diags := performEncryptionAtRest(ctx, d, c, cUUID,
+		d.Timeout(schema.TimeoutCreate), abortOnCancel); diags != nil {
+		return diags
+	}
+	upgradeOption, sleepAfterMasterMs, sleepAfterTServerMs := nodeRestartSettings(d)
+	if diags := performConnectionPooling(ctx, d, c, cUUID, upgradeOption,
+		sleepAfterMasterMs, sleepAfterTServerMs, d.Timeout(schema.TimeoutCreate),
+		abortOnCancel); diags != nil {
+		return diags
+	}
+	if d.Get("paused").(bool) {
+		if diags := setUniversePaused(ctx, d, c, cUUID,
+			d.Timeout(schema.TimeoutCreate), abortOnCancel, true); diags != nil {
+			return diags
+		}
+	}
 	return resourceUniverseRead(ctx, d, meta)
 }
 
@@ -3023,6 +3024,13 @@ func resourceUniverseRead(
 
 	c := meta.(*api.APIClient).YugawareClient
 	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+
+	diags = append(diags, utils.ResumePendingTask(ctx, d, cUUID, c,
+		d.Timeout(schema.TimeoutUpdate), abortOnCancel)...)
+	if diags.HasError() {
+		return diags
+	}
 
 	r, response, err := c.UniverseManagementAPI.GetUniverse(ctx, cUUID, d.Id()).Execute()
 	if err != nil {
@@ -3052,15 +3060,20 @@ func resourceUniverseRead(
 	if err = d.Set("arch", u.GetArch()); err != nil {
 		return diag.FromErr(err)
 	}
-	newClusters := flattenClusters(u.Clusters)
+	clusters := universeClusters(d, u.Clusters)
+	newClusters := flattenClusters(clusters)
 	oldClusters := d.Get("clusters").([]interface{})
 	diags = append(diags, restoreRedactedPasswords(ctx, newClusters, oldClusters)...)
+	stripDefaultTags(newClusters, oldClusters, meta.(*api.APIClient).DefaultTags())
 	alignClustersCloudList(newClusters, oldClusters)
-	restoreDedicatedMasterFields(newClusters, oldClusters, u.Clusters, d.GetRawConfig())
+	restoreDedicatedMasterFields(newClusters, oldClusters, clusters, d.GetRawConfig())
 	pruneSpecificGFlagsByConfig(newClusters, d.GetRawConfig())
 	if err = d.Set("clusters", newClusters); err != nil {
 		return diag.FromErr(err)
 	}
+	if err = d.Set("instance_tags_all", flattenInstanceTagsAll(clusters)); err != nil {
+		return diag.FromErr(err)
+	}
 	err = d.Set("communication_ports", flattenCommunicationPorts(u.CommunicationPorts))
 	if err != nil {
 		return diag.FromErr(err)
@@ -3069,9 +3082,24 @@ func resourceUniverseRead(
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	err = d.Set("node_instance_tags", nodeInstanceTags(
+		d.Get("instance_tags_all").([]interface{}), d.Get("clusters").([]interface{}),
+		d.Get("node_details_set").([]interface{})))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	if err = d.Set("db_version_upgrade_state", u.GetSoftwareUpgradeState()); err != nil {
 		return diag.FromErr(err)
 	}
+	if err = d.Set("paused", u.GetUniversePaused()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("failed_task_uuid", failedTaskUUID(u)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = readEncryptionAtRest(ctx, d, c, cUUID, u); err != nil {
+		return diag.FromErr(err)
+	}
 	return diags
 }
 
@@ -3277,6 +3305,76 @@ func simulateAllUserIntentChanges(
 	return changed || dedicatedChanged, intent
 }
 
+// nodeRestartSettingsSchema is the node_restart_settings block shared by
+// yba_universe and yba_universe_read_replica; read it with nodeRestartSettings.
+func nodeRestartSettingsSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"upgrade_option": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  "Rolling",
+					ValidateDiagFunc: validation.ToDiagFunc(
+						validation.StringInSlice(
+							[]string{"Rolling", "Non-Rolling", "Non-Restart"}, false)),
+					Description: "Node restart strategy applied to all upgrade operations. " +
+						"Allowed values: Rolling, Non-Rolling, Non-Restart. Defaults to " +
+						"Rolling (YugabyteDB Anywhere platform default). TLS toggle always " +
+						"uses Non-Rolling; ResizeNode and VMImageUpgrade always use Rolling, " +
+						"regardless of this setting.",
+				},
+				"sleep_after_master_restart_millis": {
+					Type:             schema.TypeInt,
+					Optional:         true,
+					Default:          180000,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+					Description: "Milliseconds to sleep after each master node restart. " +
+						"Must be 0 or a positive integer. Defaults to 180000 (3 minutes), " +
+						"matching the YugabyteDB Anywhere platform default.",
+				},
+				"sleep_after_tserver_restart_millis": {
+					Type:             schema.TypeInt,
+					Optional:         true,
+					Default:          180000,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+					Description: "Milliseconds to sleep after each TServer node restart. " +
+						"Must be 0 or a positive integer. Defaults to 180000 (3 minutes), " +
+						"matching the YugabyteDB Anywhere platform default.",
+				},
+			},
+		},
+	}
+}
+
+// nodeRestartSettings reads node_restart_settings with explicit fallbacks. When
+// the block is absent, d.Get returns zero values ("" / 0) rather than the schema
+// defaults, so the YBA platform defaults are applied here: Rolling strategy,
+// 180000 ms sleep (3 minutes).
+func nodeRestartSettings(d *schema.ResourceData) (string, int32, int32) {
+	upgradeOption := d.Get("node_restart_settings.0.upgrade_option").(string)
+	if upgradeOption == "" {
+		upgradeOption = "Rolling"
+	}
+	sleepAfterMasterMs := int32(
+		d.Get("node_restart_settings.0.sleep_after_master_restart_millis").(int),
+	)
+	if sleepAfterMasterMs == 0 {
+		sleepAfterMasterMs = 180000
+	}
+	sleepAfterTServerMs := int32(
+		d.Get("node_restart_settings.0.sleep_after_tserver_restart_millis").(int),
+	)
+	if sleepAfterTServerMs == 0 {
+		sleepAfterTServerMs = 180000
+	}
+	return upgradeOption, sleepAfterMasterMs, sleepAfterTServerMs
+}
+
 func editUniverseParameters(ctx context.Context, oldUserIntent client.UserIntent,
 	newUserIntent client.UserIntent) (bool, client.UserIntent) {
 	// masterInstanceType changes are applied via ResizeNode (same API path the
@@ -3303,7 +3401,7 @@ func editUniverseParameters(ctx context.Context, oldUserIntent client.UserIntent
 		oldUserIntent.DeviceInfo.GetNumVolumes() != newUserIntent.DeviceInfo.GetNumVolumes() ||
 		oldUserIntent.DeviceInfo.GetVolumeSize() != newUserIntent.DeviceInfo.GetVolumeSize() ||
 		oldUserIntent.DeviceInfo.GetStorageType() != newUserIntent.DeviceInfo.GetStorageType() ||
-		dedicatedMasterChanged {
+		dedicatedMasterChanged || kubernetesResourcesChanged(oldUserIntent, newUserIntent) {
 
 		// Full-move warnings. Plan-time full_move.allow gates have already
 		// enforced user consent; these just surface which specific change is
@@ -3360,6 +3458,14 @@ func editUniverseParameters(ctx context.Context, oldUserIntent client.UserIntent
 		oldUserIntent.DeviceInfo.StorageType = newUserIntent.DeviceInfo.StorageType
 		oldUserIntent.DeviceInfo.DiskIops = newUserIntent.DeviceInfo.DiskIops
 		oldUserIntent.DeviceInfo.Throughput = newUserIntent.DeviceInfo.Throughput
+		// Pod resources of a Kubernetes universe. A nil spec means the
+		// kubernetes block is absent and keeps the current resources.
+		if newUserIntent.TserverK8SNodeResourceSpec != nil {
+			oldUserIntent.TserverK8SNodeResourceSpec = newUserIntent.TserverK8SNodeResourceSpec
+		}
+		if newUserIntent.MasterK8SNodeResourceSpec != nil {
+			oldUserIntent.MasterK8SNodeResourceSpec = newUserIntent.MasterK8SNodeResourceSpec
+		}
 		// When dedicatedNodes is being toggled on or off, propagate the full set of
 		// master fields (DedicatedNodes flag, MasterInstanceType, MasterDeviceInfo)
 		// into the UpdatePrimaryCluster request. There are no existing dedicated master
@@ -3396,6 +3502,7 @@ func runFinalizeUpgrade(
 	sleepAfterMasterMs int32,
 	sleepAfterTServerMs int32,
 	timeout time.Duration,
+	abortOnCancel bool,
 ) diag.Diagnostics {
 	finalizeReq := client.FinalizeUpgradeParams{
 		Clusters:                       clusters,
@@ -3404,7 +3511,7 @@ func runFinalizeUpgrade(
 		SleepAfterMasterRestartMillis:  sleepAfterMasterMs,
 		SleepAfterTServerRestartMillis: sleepAfterTServerMs,
 	}
-	return utils.DispatchAndWait(ctx, "Finalize Upgrade", cUUID, c, timeout,
+	return utils.DispatchAndWait(ctx, "Finalize Upgrade", cUUID, c, timeout, abortOnCancel,
 		utils.ResourceEntity, "Universe", "Update - Finalize Upgrade",
 		func() (string, *http.Response, error) {
 			r, resp, err := c.UniverseUpgradesManagementAPI.FinalizeUpgrade(
@@ -3425,15 +3532,44 @@ func resourceUniverseUpdate(
 	// cloud Info can have changes in zones
 	c := meta.(*api.APIClient).YugawareClient
 	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
 
 	defer func() {
 		diags = append(resourceUniverseRead(ctx, d, meta), diags...)
 	}()
 
+	diags = utils.ResumePendingTask(ctx, d, cUUID, c, d.Timeout(schema.TimeoutUpdate), abortOnCancel)
+	if diags.HasError() {
+		return diags
+	}
+	ctx = utils.TrackPendingTask(ctx, d)
+
+	// A paused universe takes no edits; resume it first when the plan does.
+	diags = append(diags, checkPausedEdits(d)...)
+	if diags.HasError() {
+		return diags
+	}
+	if d.HasChange("paused") && !d.Get("paused").(bool) {
+		diags = append(diags, setUniversePaused(ctx, d, c, cUUID,
+			d.Timeout(schema.TimeoutUpdate), abortOnCancel, false)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	// Retry a failed last task before dispatching anything on top of it.
+	diags = append(diags, retryFailedTask(ctx, d, c, cUUID, abortOnCancel)...)
+	if diags.HasError() {
+		return diags
+	}
+
 	// Reject any attempt to change ports that are immutable after universe creation.
 	if err := validateCommPortsNotRestricted(d); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := resolveInstanceTagsAll(ctx, d, meta); err != nil {
+		return diag.FromErr(err)
+	}
 
 	// Validate cloud_list before any API mutations. These checks are skipped in
 	// CustomizeDiff for existing resources to avoid firing during terraform
@@ -3638,25 +3774,7 @@ func resourceUniverseUpdate(
 	// --- END PRE-FLIGHT CHECK ---
 	// =========================================================================
 
-	// Read node_restart_settings once with explicit fallbacks. When the block is absent,
-	// d.Get returns zero values ("" / 0) rather than the schema defaults, so we apply the
-	// YBA platform defaults here: Rolling strategy, 180000 ms sleep (3 minutes).
-	upgradeOption := d.Get("node_restart_settings.0.upgrade_option").(string)
-	if upgradeOption == "" {
-		upgradeOption = "Rolling"
-	}
-	sleepAfterMasterMs := int32(
-		d.Get("node_restart_settings.0.sleep_after_master_restart_millis").(int),
-	)
-	if sleepAfterMasterMs == 0 {
-		sleepAfterMasterMs = 180000
-	}
-	sleepAfterTServerMs := int32(
-		d.Get("node_restart_settings.0.sleep_after_tserver_restart_millis").(int),
-	)
-	if sleepAfterTServerMs == 0 {
-		sleepAfterTServerMs = 180000
-	}
+	upgradeOption, sleepAfterMasterMs, sleepAfterTServerMs := nodeRestartSettings(d)
 
 	// Rollback is a universe-level operation (not per-cluster): the YBA handler reads
 	// prevYBSoftwareConfig from universe-wide details to determine the version to revert to,
@@ -3685,7 +3803,7 @@ func resourceUniverseUpdate(
 				SleepAfterTServerRestartMillis: sleepAfterTServerMs,
 			}
 			if diags := utils.DispatchAndWait(ctx, "Rollback Upgrade", cUUID, c,
-				d.Timeout(schema.TimeoutUpdate),
+				d.Timeout(schema.TimeoutUpdate), abortOnCancel,
 				utils.ResourceEntity, "Universe", "Update - Rollback Upgrade",
 				func() (string, *http.Response, error) {
 					r, resp, err := c.UniverseUpgradesManagementAPI.RollbackUpgrade(
@@ -3741,7 +3859,7 @@ func resourceUniverseUpdate(
 					currentUni.UniverseDetails.Clusters,
 					upgradeOption,
 					sleepAfterMasterMs, sleepAfterTServerMs,
-					d.Timeout(schema.TimeoutUpdate)); diags != nil {
+					d.Timeout(schema.TimeoutUpdate), abortOnCancel); diags != nil {
 					return diags
 				}
 			}
@@ -3750,7 +3868,9 @@ func resourceUniverseUpdate(
 
 	// True once a cluster edit dispatches with communication_ports bundled in.
 	portsBundledInClusterEdit := false
-	if d.HasChange("clusters") {
+	// A change of the provider's default_tags only shows in instance_tags_all;
+	// the cluster edit below carries it like any other tag change.
+	if d.HasChanges("clusters", "instance_tags_all") {
 		clusters := d.Get("clusters").([]interface{})
 		updateUni, response, err := c.UniverseManagementAPI.GetUniverse(ctx, cUUID, d.Id()).
 			Execute()
@@ -3800,7 +3920,7 @@ func resourceUniverseUpdate(
 		if len(imageBundleUpgrades) > 0 && hasScaleOut {
 			if diagErr := performVMImageUpgrade(
 				ctx, c, cUUID, d, updateUni, imageBundleUpgrades,
-				sleepAfterMasterMs, sleepAfterTServerMs,
+				sleepAfterMasterMs, sleepAfterTServerMs, abortOnCancel,
 			); diagErr != nil {
 				return diagErr
 			}
@@ -3825,7 +3945,10 @@ func resourceUniverseUpdate(
 					ctx,
 					"Currently not supporting adding Read Replicas after universe creation",
 				)
-			} else if len(updateUni.UniverseDetails.Clusters) > len(clusters) {
+			} else if len(updateUni.UniverseDetails.Clusters) > len(clusters) &&
+				!d.Get("external_read_replica").(bool) {
+				// A read replica managed by yba_universe_read_replica is not
+				// declared in clusters and must be left alone.
 				var clusterUUID string
 				for _, v := range updateUni.UniverseDetails.Clusters {
 					if v.ClusterType == "ASYNC" {
@@ -3834,7 +3957,7 @@ func resourceUniverseUpdate(
 				}
 
 				if diags := utils.DispatchAndWait(ctx, "Delete Read Replica Cluster", cUUID, c,
-					d.Timeout(schema.TimeoutUpdate),
+					d.Timeout(schema.TimeoutUpdate), abortOnCancel,
 					utils.ResourceEntity, "Universe", "Update - Delete Read Replica cluster",
 					func() (string, *http.Response, error) {
 						r, resp, delErr := c.UniverseClusterMutationsAPI.DeleteReadonlyCluster(
@@ -3863,7 +3986,8 @@ func resourceUniverseUpdate(
 			}
 		}
 		for i, v := range clusters {
-			if !d.HasChange(fmt.Sprintf("clusters.%d", i)) {
+			if !d.HasChanges(fmt.Sprintf("clusters.%d", i),
+				fmt.Sprintf("instance_tags_all.%d", i)) {
 				continue
 			}
 			cluster := v.(map[string]interface{})
@@ -3883,6 +4007,19 @@ func resourceUniverseUpdate(
 
 					finalize := d.Get("db_version_upgrade_options.0.finalize").(bool)
 
+					// A YSQL major version upgrade only starts once YBA's
+					// precheck has passed on the live universe.
+					if isYsqlMajorUpgrade(oldUserIntent.GetYbSoftwareVersion(),
+						newUserIntent.GetYbSoftwareVersion()) {
+						if diags := runYsqlMajorUpgradePrecheck(ctx, c, cUUID, d,
+							updateUni.UniverseDetails.Clusters,
+							newUserIntent.GetYbSoftwareVersion(), upgradeOption,
+							sleepAfterMasterMs, sleepAfterTServerMs,
+							d.Timeout(schema.TimeoutUpdate), abortOnCancel); diags != nil {
+							return diags
+						}
+					}
+
 					req := client.SoftwareUpgradeParams{
 						YbSoftwareVersion:              newUserIntent.GetYbSoftwareVersion(),
 						Clusters:                       updateUni.UniverseDetails.Clusters,
@@ -3893,7 +4030,7 @@ func resourceUniverseUpdate(
 					}
 
 					if diags := utils.DispatchAndWait(ctx, "DB Version Upgrade", cUUID, c,
-						d.Timeout(schema.TimeoutUpdate),
+						d.Timeout(schema.TimeoutUpdate), abortOnCancel,
 						utils.ResourceEntity, "Universe", "Update - DB Version Upgrade",
 						func() (string, *http.Response, error) {
 							r, resp, e := c.UniverseUpgradesManagementAPI.UpgradeDBVersion(
@@ -3925,7 +4062,7 @@ func resourceUniverseUpdate(
 								updateUni.UniverseDetails.Clusters,
 								upgradeOption,
 								sleepAfterMasterMs, sleepAfterTServerMs,
-								d.Timeout(schema.TimeoutUpdate)); diags != nil {
+								d.Timeout(schema.TimeoutUpdate), abortOnCancel); diags != nil {
 								return diags
 							}
 						} else {
@@ -3961,7 +4098,7 @@ func resourceUniverseUpdate(
 						SleepAfterTServerRestartMillis: sleepAfterTServerMs,
 					}
 					if diags := utils.DispatchAndWait(ctx, "GFlags Upgrade", cUUID, c,
-						d.Timeout(schema.TimeoutUpdate),
+						d.Timeout(schema.TimeoutUpdate), abortOnCancel,
 						utils.ResourceEntity, "Universe", "Update - GFlags",
 						func() (string, *http.Response, error) {
 							r, resp, e := c.UniverseUpgradesManagementAPI.UpgradeGFlags(
@@ -3976,6 +4113,17 @@ func resourceUniverseUpdate(
 					}
 				}
 
+				// Helm overrides of a Kubernetes universe. The software and gflags
+				// upgrades above use the same endpoints as on VMs; YBA runs their
+				// Kubernetes variants for universes on a Kubernetes provider.
+				if oldUserIntent.GetProviderType() == providerutil.K8sProviderCode &&
+					kubernetesOverridesChanged(oldUserIntent, newUserIntent) {
+					if diags := performKubernetesOverridesUpgrade(ctx, c, cUUID, d, updateUni, i,
+						newUserIntent, sleepAfterMasterMs, sleepAfterTServerMs, abortOnCancel); diags != nil {
+						return diags
+					}
+				}
+
 				updateUni, response, err = c.UniverseManagementAPI.GetUniverse(ctx, cUUID,
 					d.Id()).Execute()
 				if err != nil {
@@ -4042,7 +4190,7 @@ func resourceUniverseUpdate(
 						req.ClientRootCA = utils.GetStringPointer(tlsClientRootCA)
 					}
 					if diags := utils.DispatchAndWait(ctx, "TLS Toggle", cUUID, c,
-						d.Timeout(schema.TimeoutUpdate),
+						d.Timeout(schema.TimeoutUpdate), abortOnCancel,
 						utils.ResourceEntity, "Universe", "Update - TLS Toggle",
 						func() (string, *http.Response, error) {
 							r, resp, e := c.UniverseUpgradesManagementAPI.UpgradeTls(
@@ -4079,7 +4227,7 @@ func resourceUniverseUpdate(
 						SleepAfterTServerRestartMillis: sleepAfterTServerMs,
 					}
 					if diags := utils.DispatchAndWait(ctx, "Systemd Upgrade", cUUID, c,
-						d.Timeout(schema.TimeoutUpdate),
+						d.Timeout(schema.TimeoutUpdate), abortOnCancel,
 						utils.ResourceEntity, "Universe", "Update - Systemd",
 						func() (string, *http.Response, error) {
 							r, resp, e := c.UniverseUpgradesManagementAPI.UpgradeSystemd(
@@ -4262,7 +4410,7 @@ func resourceUniverseUpdate(
 							SleepAfterTServerRestartMillis: sleepAfterTServerMs,
 						}
 						if diags := utils.DispatchAndWait(ctx, "Resize Nodes", cUUID, c,
-							d.Timeout(schema.TimeoutUpdate),
+							d.Timeout(schema.TimeoutUpdate), abortOnCancel,
 							utils.ResourceEntity, "Universe", "Update - Resize Nodes",
 							func() (string, *http.Response, error) {
 								r, resp, e := c.UniverseUpgradesManagementAPI.ResizeNode(
@@ -4388,7 +4536,7 @@ func resourceUniverseUpdate(
 						}
 
 						if diags := utils.DispatchAndWait(ctx, "Update Primary Cluster", cUUID, c,
-							d.Timeout(schema.TimeoutUpdate),
+							d.Timeout(schema.TimeoutUpdate), abortOnCancel,
 							utils.ResourceEntity, "Universe", "Update - Primary Cluster",
 							func() (string, *http.Response, error) {
 								r, resp, e := c.UniverseClusterMutationsAPI.UpdatePrimaryCluster(
@@ -4580,7 +4728,7 @@ func resourceUniverseUpdate(
 							"Resize Nodes (Read Replica)",
 							cUUID,
 							c,
-							d.Timeout(schema.TimeoutUpdate),
+							d.Timeout(schema.TimeoutUpdate), abortOnCancel,
 							utils.ResourceEntity,
 							"Universe",
 							"Update - Resize Nodes (Read Replica)",
@@ -4666,7 +4814,7 @@ func resourceUniverseUpdate(
 							"Update Read Replica Cluster",
 							cUUID,
 							c,
-							d.Timeout(schema.TimeoutUpdate),
+							d.Timeout(schema.TimeoutUpdate), abortOnCancel,
 							utils.ResourceEntity,
 							"Universe",
 							"Update - Read Replica Cluster",
@@ -4717,7 +4865,7 @@ func resourceUniverseUpdate(
 			if len(gflagChanges) > 0 {
 				if diagErr := performGFlagsUpgrade(
 					ctx, c, cUUID, d, updateUni, gflagChanges,
-					upgradeOption, sleepAfterMasterMs, sleepAfterTServerMs,
+					upgradeOption, sleepAfterMasterMs, sleepAfterTServerMs, abortOnCancel,
 				); diagErr != nil {
 					return diagErr
 				}
@@ -4738,13 +4886,23 @@ func resourceUniverseUpdate(
 			}
 			if diagErr := performVMImageUpgrade(
 				ctx, c, cUUID, d, updateUni, imageBundleUpgrades,
-				sleepAfterMasterMs, sleepAfterTServerMs,
+				sleepAfterMasterMs, sleepAfterTServerMs, abortOnCancel,
 			); diagErr != nil {
 				return diagErr
 			}
 		}
 	}
 
+	// Connection pooling follows the cluster edits, so its restart covers the
+	// final node set.
+	if d.HasChange("clusters") {
+		if diags := performConnectionPooling(ctx, d, c, cUUID, upgradeOption,
+			sleepAfterMasterMs, sleepAfterTServerMs, d.Timeout(schema.TimeoutUpdate),
+			abortOnCancel); diags != nil {
+			return diags
+		}
+	}
+
 	// Apply port changes not already bundled into a cluster edit (gflag /
 	// software / TLS-only edits route elsewhere and don't carry ports).
 	if d.HasChange("communication_ports") && !portsBundledInClusterEdit {
@@ -4802,7 +4960,7 @@ func resourceUniverseUpdate(
 			XclusterInfo:            fetchedUni.UniverseDetails.XclusterInfo,
 		}
 		if diags := utils.DispatchAndWait(ctx, "Update Communication Ports", cUUID, c,
-			d.Timeout(schema.TimeoutUpdate),
+			d.Timeout(schema.TimeoutUpdate), abortOnCancel,
 			utils.ResourceEntity, "Universe", "Update - Communication Ports",
 			func() (string, *http.Response, error) {
 				r, resp, err := c.UniverseClusterMutationsAPI.UpdatePrimaryCluster(
@@ -4825,6 +4983,25 @@ func resourceUniverseUpdate(
 		return certDiags
 	}
 
+	// Encryption at rest needs no restart, so it follows the edits above
+	// rather than racing them.
+	if earDiags := performEncryptionAtRest(ctx, d, c, cUUID,
+		d.Timeout(schema.TimeoutUpdate), abortOnCancel); earDiags != nil {
+		return earDiags
+	}
+
+	// Password resets need no restart but a running universe, so they precede
+	// pausing.
+	if pwDiags := performPasswordRotation(ctx, d, c, cUUID); pwDiags != nil {
+		return pwDiags
+	}
+
+	// Pausing runs after every other change, which needs the universe running.
+	if d.HasChange("paused") && d.Get("paused").(bool) {
+		diags = append(diags, setUniversePaused(ctx, d, c, cUUID,
+			d.Timeout(schema.TimeoutUpdate), abortOnCancel, true)...)
+	}
+
 	return
 }
 
@@ -4837,6 +5014,7 @@ func performVMImageUpgrade(
 	imageBundleUpgrades []client.ImageBundleUpgradeInfo,
 	sleepAfterMasterMs int32,
 	sleepAfterTServerMs int32,
+	abortOnCancel bool,
 ) diag.Diagnostics {
 	for _, ibUpgrade := range imageBundleUpgrades {
 		for k := range updateUni.UniverseDetails.Clusters {
@@ -4855,7 +5033,7 @@ func performVMImageUpgrade(
 		SleepAfterTServerRestartMillis: sleepAfterTServerMs,
 	}
 	return utils.DispatchAndWait(ctx, "VM Image Upgrade", cUUID, c,
-		d.Timeout(schema.TimeoutUpdate),
+		d.Timeout(schema.TimeoutUpdate), abortOnCancel,
 		utils.ResourceEntity, "Universe", "Update - VM Image",
 		func() (string, *http.Response, error) {
 			r, resp, err := c.UniverseUpgradesManagementAPI.UpgradeVMImage(
@@ -4880,6 +5058,7 @@ func performGFlagsUpgrade(
 	upgradeOption string,
 	sleepAfterMasterMs int32,
 	sleepAfterTServerMs int32,
+	abortOnCancel bool,
 ) diag.Diagnostics {
 	if len(changedByIdx) == 0 {
 		return nil
@@ -4907,7 +5086,7 @@ func performGFlagsUpgrade(
 		SleepAfterTServerRestartMillis: sleepAfterTServerMs,
 	}
 	return utils.DispatchAndWait(ctx, "GFlags Upgrade", cUUID, c,
-		d.Timeout(schema.TimeoutUpdate),
+		d.Timeout(schema.TimeoutUpdate), abortOnCancel,
 		utils.ResourceEntity, "Universe", "Update - GFlags",
 		func() (string, *http.Response, error) {
 			r, resp, err := c.UniverseUpgradesManagementAPI.UpgradeGFlags(
@@ -4937,13 +5116,7 @@ var unrecoverableFailedTasks = map[string]bool{
 func requiresForceDelete(ctx context.Context,
 	c *client.APIClient,
 	cUUID string, details *client.UniverseDefinitionTaskParamsResp) bool {
-	if details == nil {
-		return false
-	}
-	if details.GetUpdateInProgress() || details.GetUpdateSucceeded() {
-		return false
-	}
-	taskUUID := details.GetUpdatingTaskUUID()
+	taskUUID := failedTaskUUID(details)
 	if taskUUID == "" {
 		return false
 	}
@@ -4978,6 +5151,7 @@ func resourceUniverseDelete(
 
 	c := meta.(*api.APIClient).YugawareClient
 	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
 	universeID := d.Id()
 
 	forceDeleteConfig := d.Get("delete_options.0.force_delete").(bool)
@@ -4989,7 +5163,7 @@ func resourceUniverseDelete(
 	// call it a second time without duplicating the boilerplate.
 	runDelete := func(force bool) diag.Diagnostics {
 		return utils.DispatchAndWait(ctx, "Delete Universe", cUUID, c,
-			d.Timeout(schema.TimeoutDelete),
+			d.Timeout(schema.TimeoutDelete), abortOnCancel,
 			utils.ResourceEntity, "Universe", "Delete",
 			func() (string, *http.Response, error) {
 				r, resp, err := c.UniverseManagementAPI.DeleteUniverse(ctx, cUUID, universeID).
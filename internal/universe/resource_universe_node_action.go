@@ -0,0 +1,277 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package universe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/api"
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+// nodeActions are the node actions yba_universe_node_action can run.
+var nodeActions = []string{
+	"START",
+	"STOP",
+	"REBOOT",
+	"HARD_REBOOT",
+	"REPLACE",
+	"REMOVE",
+	"RELEASE",
+	"REPROVISION",
+}
+
+// ResourceUniverseNodeAction runs an action on a node of a universe
+func ResourceUniverseNodeAction() *schema.Resource {
+	return &schema.Resource{
+		Description: "Run an action (start, stop, reboot, replace, release...) on a node of " +
+			"a universe. The action runs when the resource is created, and again whenever " +
+			"action changes or trigger changes to a new non-empty value; the apply waits " +
+			"for it to complete. Destroying the resource only removes it from state.",
+
+		CreateContext: resourceUniverseNodeActionCreate,
+		ReadContext:   resourceUniverseNodeActionRead,
+		UpdateContext: resourceUniverseNodeActionUpdate,
+		DeleteContext: resourceUniverseNodeActionDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"universe_uuid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "UUID of the universe.",
+			},
+			"node_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "Name of the node, as reported in the node_details_set of the " +
+					"universe (e.g., yb-dev-orders-n1).",
+			},
+			"action": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice(nodeActions, false)),
+				Description: "Action to run on the node. Allowed values: " +
+					strings.Join(nodeActions, ", ") + ". YugabyteDB Anywhere only allows " +
+					"some actions in each node state (see allowed_actions); others are " +
+					"rejected before anything is dispatched.",
+			},
+			"trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Changing this value to any new non-empty value runs the " +
+					"action again (e.g., a date or a time_rotating value). Clearing it " +
+					"does not.",
+			},
+			"node_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "State of the node (e.g., Live, Stopped, Removed, Decommissioned).",
+			},
+			"allowed_actions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Actions YugabyteDB Anywhere allows on the node in its current state.",
+			},
+			"task_uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "UUID of the YugabyteDB Anywhere task of the last action.",
+			},
+			utils.PendingTaskUUIDKey: utils.PendingTaskUUIDSchema(),
+		},
+	}
+}
+
+// findUniverseNode returns the node of the universe with the given name, or
+// nil when the universe has no such node.
+func findUniverseNode(u *client.UniverseResp, nodeName string) *client.NodeDetailsResp {
+	if u.UniverseDetails == nil {
+		return nil
+	}
+	for i, n := range u.UniverseDetails.NodeDetailsSet {
+		if n.GetNodeName() == nodeName {
+			return &u.UniverseDetails.NodeDetailsSet[i]
+		}
+	}
+	return nil
+}
+
+// checkNodeAction returns an error when YBA does not allow action on node in
+// its current state.
+func checkNodeAction(node *client.NodeDetailsResp, action string) error {
+	allowed := node.GetAllowedActions()
+	if slices.Contains(allowed, action) {
+		return nil
+	}
+	return fmt.Errorf("action %s is not allowed on node %s in state %s; allowed actions: %s",
+		action, node.GetNodeName(), node.GetState(), strings.Join(allowed, ", "))
+}
+
+// performNodeAction runs action on the node and waits for it.
+func performNodeAction(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{},
+	timeout time.Duration,
+) diag.Diagnostics {
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+
+	universeUUID := d.Get("universe_uuid").(string)
+	nodeName := d.Get("node_name").(string)
+	action := d.Get("action").(string)
+
+	u, response, err := c.UniverseManagementAPI.GetUniverse(ctx, cUUID, universeUUID).Execute()
+	if err != nil {
+		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"Universe Node Action", "Fetch universe")
+		return diag.FromErr(errMessage)
+	}
+	node := findUniverseNode(u, nodeName)
+	if node == nil {
+		return diag.Errorf("Universe %s has no node %s", universeUUID, nodeName)
+	}
+	if err := checkNodeAction(node, action); err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, "Running node action", map[string]interface{}{
+		"universe_uuid": universeUUID,
+		"node_name":     nodeName,
+		"action":        action,
+	})
+	req := client.NodeActionFormData{NodeAction: action}
+	return utils.DispatchAndWait(utils.TrackPendingTask(ctx, d), "Node Action "+action, cUUID,
+		c, timeout, abortOnCancel,
+		utils.ResourceEntity, "Universe Node Action", action,
+		func() (string, *http.Response, error) {
+			r, resp, err := c.NodeInstancesAPI.NodeAction(ctx, cUUID, universeUUID, nodeName).
+				NodeAction(req).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			if d.Id() == "" {
+				d.SetId(universeUUID + "/" + nodeName)
+			}
+			if err := d.Set("task_uuid", r.GetTaskUUID()); err != nil {
+				return "", resp, err
+			}
+			return r.GetTaskUUID(), resp, nil
+		},
+	)
+}
+
+func resourceUniverseNodeActionCreate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	if diags := performNodeAction(ctx, d, meta, d.Timeout(schema.TimeoutCreate)); diags != nil {
+		return diags
+	}
+	return resourceUniverseNodeActionRead(ctx, d, meta)
+}
+
+func resourceUniverseNodeActionRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+
+	diags = append(diags, utils.ResumePendingTask(ctx, d, cUUID, c,
+		d.Timeout(schema.TimeoutUpdate), abortOnCancel)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	universeUUID := d.Get("universe_uuid").(string)
+	u, response, err := c.UniverseManagementAPI.GetUniverse(ctx, cUUID, universeUUID).Execute()
+	if err != nil {
+		if utils.IsHTTPNotFound(response) || utils.IsHTTPBadRequestNotFound(response) {
+			tflog.Warn(ctx, fmt.Sprintf("Universe %s not found, removing node action "+
+				"from state: %v", universeUUID, err))
+			d.SetId("")
+			return diags
+		}
+		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"Universe Node Action", "Read")
+		return diag.FromErr(errMessage)
+	}
+	// A node that was replaced or deleted reports no state; the resource is
+	// kept so its action is not run again.
+	state, allowed := "", []string{}
+	if node := findUniverseNode(u, d.Get("node_name").(string)); node != nil {
+		state, allowed = node.GetState(), node.GetAllowedActions()
+	}
+	if err = d.Set("node_state", state); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("allowed_actions", allowed); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func resourceUniverseNodeActionUpdate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	if !d.HasChange("action") && !utils.TriggerFired(d, "trigger") {
+		return resourceUniverseNodeActionRead(ctx, d, meta)
+	}
+	if diags := performNodeAction(ctx, d, meta, d.Timeout(schema.TimeoutUpdate)); diags != nil {
+		// Keep the previous values so the next apply runs the action again,
+		// unless it is still running and the next Read waits for it.
+		if diags.HasError() && utils.PendingTaskUUID(d) == "" {
+			utils.RevertFields(d, "action", "trigger")
+		}
+		return diags
+	}
+	return resourceUniverseNodeActionRead(ctx, d, meta)
+}
+
+func resourceUniverseNodeActionDelete(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	// Node actions cannot be undone; the resource is only removed from state.
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,60 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package universe
+
+import (
+	"testing"
+
+	client "github.com/yugabyte/platform-go-client"
+)
+
+func TestCheckNodeAction(t *testing.T) {
+	node := client.NodeDetailsResp{}
+	node.SetNodeName("yb-dev-orders-n1")
+	node.SetState("Live")
+	node.SetAllowedActions([]string{"STOP", "REBOOT", "HARD_REBOOT", "REMOVE"})
+
+	for action, wantErr := range map[string]bool{
+		"REBOOT":  false,
+		"STOP":    false,
+		"START":   true,
+		"RELEASE": true,
+	} {
+		err := checkNodeAction(&node, action)
+		if (err != nil) != wantErr {
+			t.Errorf("checkNodeAction(%s) error = %v, want error %v", action, err, wantErr)
+		}
+	}
+}
+
+func TestFindUniverseNode(t *testing.T) {
+	n1, n2 := client.NodeDetailsResp{}, client.NodeDetailsResp{}
+	n1.SetNodeName("yb-dev-orders-n1")
+	n2.SetNodeName("yb-dev-orders-n2")
+	u := &client.UniverseResp{UniverseDetails: &client.UniverseDefinitionTaskParamsResp{
+		NodeDetailsSet: []client.NodeDetailsResp{n1, n2},
+	}}
+	if node := findUniverseNode(u, "yb-dev-orders-n2"); node == nil ||
+		node.GetNodeName() != "yb-dev-orders-n2" {
+		t.Errorf("findUniverseNode(n2) = %v, want node n2", node)
+	}
+	if node := findUniverseNode(u, "yb-dev-orders-n3"); node != nil {
+		t.Errorf("findUniverseNode(n3) = %v, want nil", node)
+	}
+	if node := findUniverseNode(&client.UniverseResp{}, "yb-dev-orders-n1"); node != nil {
+		t.Errorf("findUniverseNode without details = %v, want nil", node)
+	}
+}
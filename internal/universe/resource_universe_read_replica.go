@@ -0,0 +1,535 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package universe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/api"
+	"github.com/yugabyte/terraform-provider-yba/internal/provider/providerutil"
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+// readReplicaPrimaryFields are the user_intent attributes a read replica
+// shares with the primary cluster, with their descriptions. YBA rejects a read
+// replica whose values differ, so yba_universe_read_replica copies them from
+// the live primary and only reports them.
+var readReplicaPrimaryFields = map[string]string{
+	"universe_name":                 "Universe name.",
+	"yb_software_version":           "YBDB version of the universe.",
+	"use_systemd":                   "Whether Systemd is enabled in universe nodes.",
+	"enable_ysql":                   "Whether YSQL is enabled.",
+	"enable_ysql_auth":              "Whether YSQL authentication is enabled.",
+	"enable_ycql":                   "Whether YCQL is enabled.",
+	"enable_ycql_auth":              "Whether YCQL authentication is enabled.",
+	"enable_yedis":                  "Whether YEDIS is enabled.",
+	"enable_node_to_node_encrypt":   "Whether node to node encryption in transit is enabled.",
+	"enable_client_to_node_encrypt": "Whether client to node encryption in transit is enabled.",
+}
+
+// readReplicaOmittedFields are the user_intent attributes that do not apply to
+// a read replica: it runs no masters, takes no database passwords and shares
+// the connection pooling of its universe.
+var readReplicaOmittedFields = []string{
+	"ysql_password",
+	"ycql_password",
+	"dedicated_masters",
+	"connection_pooling",
+}
+
+// externalReadReplicaSchema is the yba_universe attribute that hands the read
+// replica over to a yba_universe_read_replica resource.
+func externalReadReplicaSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: "Set when the read replica of the universe is managed by a " +
+			"yba_universe_read_replica resource. The universe then ignores its ASYNC " +
+			"cluster: it is not read into clusters, and declaring no ASYNC cluster does " +
+			"not delete it. clusters must not declare an ASYNC cluster when set. " +
+			"False by default.",
+	}
+}
+
+// validateExternalReadReplica rejects an ASYNC cluster in a universe whose
+// read replica is managed by yba_universe_read_replica.
+func validateExternalReadReplica(
+	ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.Get("external_read_replica").(bool) {
+		return nil
+	}
+	if _, ok := getClusterByType(buildClusters(d.Get("clusters").([]interface{})),
+		"ASYNC"); ok {
+		return fmt.Errorf("clusters declares an ASYNC cluster but external_read_replica " +
+			"is set; manage the read replica with yba_universe_read_replica instead")
+	}
+	return nil
+}
+
+// universeClusters returns the clusters yba_universe manages: all of them, or
+// all but the read replica when it is managed externally.
+func universeClusters(d *schema.ResourceData, clusters []client.Cluster) []client.Cluster {
+	if !d.Get("external_read_replica").(bool) {
+		return clusters
+	}
+	return slices.DeleteFunc(slices.Clone(clusters), func(c client.Cluster) bool {
+		return c.ClusterType == "ASYNC"
+	})
+}
+
+// ResourceUniverseReadReplica manages the read replica (ASYNC) cluster of a
+// universe independently of the yba_universe resource
+func ResourceUniverseReadReplica() *schema.Resource {
+	return &schema.Resource{
+		Description: "Read replica cluster of a universe, managed separately from the " +
+			"yba_universe resource. The universe must set external_read_replica = true.",
+
+		CreateContext: resourceUniverseReadReplicaCreate,
+		ReadContext:   resourceUniverseReadReplicaRead,
+		UpdateContext: resourceUniverseReadReplicaUpdate,
+		DeleteContext: resourceUniverseReadReplicaDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"universe_uuid": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "UUID of the universe. Changing this value forces resource " +
+					"recreation.",
+			},
+			"cluster_uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "UUID of the read replica cluster.",
+			},
+			"user_intent": {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Required: true,
+				Elem:     readReplicaUserIntentSchema(),
+				Description: "Configuration of the read replica nodes. Attributes the " +
+					"read replica shares with the primary cluster (universe_name, " +
+					"yb_software_version, the YSQL/YCQL/YEDIS and encryption settings, " +
+					"use_systemd) are taken from the primary cluster and are read-only.",
+			},
+			"cloud_list": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     cloudListSchema(),
+				Description: "Explicit per-zone placement for the read replica. " +
+					"When omitted, YBA distributes nodes across zones automatically.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return k == "cloud_list.#" && new == "0" && old != "0"
+				},
+			},
+			"force_delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Delete the read replica even when its nodes report errors. " +
+					"False by default.",
+			},
+			"node_restart_settings": nodeRestartSettingsSchema(
+				"Controls how the read replica nodes restart during a GFlags upgrade. " +
+					"When omitted, YugabyteDB Anywhere platform defaults apply: Rolling " +
+					"strategy with 180000 ms (3 minutes) sleep after each TServer restart."),
+			utils.PendingTaskUUIDKey: utils.PendingTaskUUIDSchema(),
+		},
+	}
+}
+
+// readReplicaUserIntentSchema is the universe user_intent schema with the
+// attributes shared with the primary cluster turned read-only.
+func readReplicaUserIntentSchema() *schema.Resource {
+	r := userIntentSchema()
+	for k, description := range readReplicaPrimaryFields {
+		r.Schema[k] = &schema.Schema{
+			Type:        r.Schema[k].Type,
+			Computed:    true,
+			Description: description + " Taken from the primary cluster.",
+		}
+	}
+	for _, k := range readReplicaOmittedFields {
+		delete(r.Schema, k)
+	}
+	return r
+}
+
+// buildReadReplicaCluster builds the ASYNC cluster in d, with the attributes
+// it shares with the primary cluster copied from primary.
+func buildReadReplicaCluster(d *schema.ResourceData, primary client.UserIntent) client.Cluster {
+	raw := utils.MapFromSingletonList(d.Get("user_intent").([]interface{}))
+	// buildUserIntent reads the passwords, which the read replica schema omits.
+	raw["ysql_password"] = ""
+	raw["ycql_password"] = ""
+	ui := buildUserIntent(raw)
+	ui.UniverseName = primary.UniverseName
+	ui.YbSoftwareVersion = primary.YbSoftwareVersion
+	ui.UseSystemd = primary.UseSystemd
+	ui.EnableYSQL = primary.EnableYSQL
+	ui.EnableYSQLAuth = primary.EnableYSQLAuth
+	ui.EnableYCQL = primary.EnableYCQL
+	ui.EnableYCQLAuth = primary.EnableYCQLAuth
+	ui.EnableYEDIS = primary.EnableYEDIS
+	ui.EnableNodeToNodeEncrypt = primary.EnableNodeToNodeEncrypt
+	ui.EnableClientToNodeEncrypt = primary.EnableClientToNodeEncrypt
+	// Read replicas run no masters and never take the database passwords.
+	ui.YsqlPassword = nil
+	ui.YcqlPassword = nil
+	ui.DedicatedNodes = utils.GetBoolPointer(false)
+	ui.MasterInstanceType = nil
+	ui.MasterDeviceInfo = nil
+
+	cluster := client.Cluster{ClusterType: "ASYNC", UserIntent: ui}
+	if cl := d.Get("cloud_list").([]interface{}); len(cl) > 0 {
+		cluster.PlacementInfo = &client.PlacementInfo{CloudList: buildCloudList(cl)}
+	}
+	return cluster
+}
+
+// readReplicaParams returns the cluster edit request for the read replica of
+// u, with the universe-wide settings YBA expects carried over from u.
+func readReplicaParams(
+	uniUUID string,
+	u *client.UniverseDefinitionTaskParamsResp,
+	clusters []client.Cluster,
+	userAZSelected bool,
+) client.UniverseConfigureTaskParams {
+	return client.UniverseConfigureTaskParams{
+		UniverseUUID:       utils.GetStringPointer(uniUUID),
+		CurrentClusterType: utils.GetStringPointer("ASYNC"),
+		Clusters:           clusters,
+		NodeDetailsSet: buildNodeDetailsRespArrayToNodeDetailsArray(
+			u.NodeDetailsSet,
+		),
+		CommunicationPorts:      u.CommunicationPorts,
+		UserAZSelected:          utils.GetBoolPointer(userAZSelected),
+		AllowInsecure:           u.AllowInsecure,
+		RootAndClientRootCASame: u.RootAndClientRootCASame,
+		RootCA:                  u.RootCA,
+		ClientRootCA:            u.ClientRootCA,
+		NodePrefix:              u.NodePrefix,
+		XclusterInfo:            u.XclusterInfo,
+	}
+}
+
+// resolveReadReplicaPlacement fills in the region and zone UUIDs of the
+// configured placement from the live one and the provider's zones.
+func resolveReadReplicaPlacement(
+	ctx context.Context,
+	c *client.APIClient,
+	cUUID string,
+	pi *client.PlacementInfo,
+	live *client.PlacementInfo,
+) {
+	if pi == nil || len(pi.CloudList) == 0 {
+		return
+	}
+	var liveCloudList []client.PlacementCloud
+	if live != nil {
+		liveCloudList = live.CloudList
+	}
+	byRegion, byAZ, byAZAttrs := fetchProviderZoneFallback(ctx, c, cUUID,
+		liveCloudList, pi.CloudList)
+	resolveAZUUIDs(pi, liveCloudList, byRegion, byAZ, byAZAttrs)
+}
+
+// fetchReadReplicaUniverse returns the universe and the index of its primary
+// and read replica clusters; the read replica index is -1 when it has none.
+func fetchReadReplicaUniverse(
+	ctx context.Context,
+	c *client.APIClient,
+	cUUID, uniUUID string,
+) (*client.UniverseResp, int, int, *http.Response, error) {
+	r, response, err := c.UniverseManagementAPI.GetUniverse(ctx, cUUID, uniUUID).Execute()
+	if err != nil {
+		return nil, -1, -1, response, err
+	}
+	primary, rr := -1, -1
+	for i, cl := range r.UniverseDetails.Clusters {
+		switch cl.ClusterType {
+		case "PRIMARY":
+			primary = i
+		case "ASYNC":
+			rr = i
+		}
+	}
+	if primary < 0 {
+		return nil, -1, -1, response, fmt.Errorf("universe %s has no primary cluster", uniUUID)
+	}
+	return r, primary, rr, response, nil
+}
+
+func resourceUniverseReadReplicaCreate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+	uniUUID := d.Get("universe_uuid").(string)
+
+	uni, primary, rr, response, err := fetchReadReplicaUniverse(ctx, c, cUUID, uniUUID)
+	if err != nil {
+		return diag.FromErr(utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"Universe Read Replica", "Create - Fetch universe"))
+	}
+	if rr >= 0 {
+		return diag.Errorf("Universe %s already has a read replica; import it with "+
+			"terraform import using the universe UUID", uniUUID)
+	}
+	u := uni.UniverseDetails
+
+	ui := utils.MapFromSingletonList(d.Get("user_intent").([]interface{}))
+	p, err := providerutil.GetProvider(ctx, c, cUUID, ui["provider"].(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	cluster := buildReadReplicaCluster(d, u.Clusters[primary].UserIntent)
+	cluster.UserIntent.ProviderType = utils.GetStringPointer(p.GetCode())
+	resolveReadReplicaPlacement(ctx, c, cUUID, cluster.PlacementInfo, nil)
+
+	req := readReplicaParams(uniUUID, u, append(slices.Clone(u.Clusters), cluster),
+		hasExplicitCloudList([]client.Cluster{cluster}))
+	req.ClusterOperation = utils.GetStringPointer("CREATE")
+
+	// The ID is the universe UUID, set before waiting so an interrupted create
+	// stays in state with its pending task.
+	ctx = utils.TrackPendingTask(ctx, d)
+	if diags := utils.DispatchAndWait(ctx, "Create Read Replica Cluster", cUUID, c,
+		d.Timeout(schema.TimeoutCreate), abortOnCancel,
+		utils.ResourceEntity, "Universe Read Replica", "Create",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.UniverseClusterMutationsAPI.CreateReadOnlyCluster(
+				ctx, cUUID, uniUUID).UniverseConfigureTaskParams(req).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			d.SetId(uniUUID)
+			return r.GetTaskUUID(), resp, nil
+		},
+	); diags != nil {
+		return diags
+	}
+	return resourceUniverseReadReplicaRead(ctx, d, meta)
+}
+
+func resourceUniverseReadReplicaRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+
+	diags = append(diags, utils.ResumePendingTask(ctx, d, cUUID, c,
+		d.Timeout(schema.TimeoutUpdate), abortOnCancel)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	uni, _, rr, response, err := fetchReadReplicaUniverse(ctx, c, cUUID, d.Id())
+	if err != nil {
+		if utils.IsHTTPNotFound(response) || utils.IsHTTPBadRequestNotFound(response) {
+			tflog.Warn(ctx, fmt.Sprintf("Universe %s not found, removing read replica "+
+				"from state: %v", d.Id(), err))
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"Universe Read Replica", "Read"))
+	}
+	if rr < 0 {
+		tflog.Warn(ctx, fmt.Sprintf("Universe %s has no read replica, removing from state",
+			d.Id()))
+		d.SetId("")
+		return diags
+	}
+	cluster := uni.UniverseDetails.Clusters[rr]
+
+	if err = d.Set("universe_uuid", d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("cluster_uuid", cluster.GetUuid()); err != nil {
+		return diag.FromErr(err)
+	}
+	ui := flattenUserIntent(cluster.UserIntent)
+	for _, k := range readReplicaOmittedFields {
+		delete(ui[0].(map[string]interface{}), k)
+	}
+	if err = d.Set("user_intent", ui); err != nil {
+		return diag.FromErr(err)
+	}
+	var cloudList []client.PlacementCloud
+	if cluster.PlacementInfo != nil {
+		cloudList = cluster.PlacementInfo.CloudList
+	}
+	err = d.Set("cloud_list", alignCloudList(flattenCloudList(cloudList),
+		d.Get("cloud_list").([]interface{})))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func resourceUniverseReadReplicaUpdate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) (diags diag.Diagnostics) {
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+
+	defer func() {
+		diags = append(resourceUniverseReadReplicaRead(ctx, d, meta), diags...)
+	}()
+
+	diags = utils.ResumePendingTask(ctx, d, cUUID, c, d.Timeout(schema.TimeoutUpdate), abortOnCancel)
+	if diags.HasError() {
+		return diags
+	}
+	ctx = utils.TrackPendingTask(ctx, d)
+
+	if !d.HasChanges("user_intent", "cloud_list") {
+		return diags
+	}
+	uni, primary, rr, response, err := fetchReadReplicaUniverse(ctx, c, cUUID, d.Id())
+	if err != nil {
+		return append(diags, diag.FromErr(utils.ErrorFromHTTPResponse(response, err,
+			utils.ResourceEntity, "Universe Read Replica", "Update - Fetch universe"))...)
+	}
+	if rr < 0 {
+		return append(diags, diag.Errorf("Universe %s no longer has a read replica",
+			d.Id())...)
+	}
+	newCluster := buildReadReplicaCluster(d, uni.UniverseDetails.Clusters[primary].UserIntent)
+
+	// GFlags go through the GFlags upgrade, as for the read replica of a
+	// yba_universe.
+	if gflagsChanged(uni.UniverseDetails.Clusters[rr].UserIntent, newCluster.UserIntent) {
+		upgradeOption, sleepAfterMasterMs, sleepAfterTServerMs := nodeRestartSettings(d)
+		diags = append(diags, performGFlagsUpgrade(ctx, c, cUUID, d, uni,
+			map[int]client.UserIntent{rr: newCluster.UserIntent},
+			upgradeOption, sleepAfterMasterMs, sleepAfterTServerMs, abortOnCancel)...)
+		if diags.HasError() {
+			return diags
+		}
+		uni, _, rr, response, err = fetchReadReplicaUniverse(ctx, c, cUUID, d.Id())
+		if err != nil {
+			return append(diags, diag.FromErr(utils.ErrorFromHTTPResponse(response, err,
+				utils.ResourceEntity, "Universe Read Replica",
+				"Update - Fetch universe after GFlags"))...)
+		}
+	}
+
+	u := uni.UniverseDetails
+	live := &u.Clusters[rr]
+	editAllowed, intent := editUniverseParameters(ctx, live.UserIntent,
+		newCluster.UserIntent)
+	live.UserIntent = intent
+
+	placementChanged := false
+	if d.HasChange("cloud_list") && newCluster.PlacementInfo != nil {
+		resolveReadReplicaPlacement(ctx, c, cUUID, newCluster.PlacementInfo,
+			live.PlacementInfo)
+		// Nodes in zones the placement drops are removed by the edit.
+		var liveCloudList []client.PlacementCloud
+		if live.PlacementInfo != nil {
+			liveCloudList = live.PlacementInfo.CloudList
+		}
+		oldAZUUIDs := collectAZUUIDs(liveCloudList)
+		newAZUUIDs := collectAZUUIDs(newCluster.PlacementInfo.CloudList)
+		for j := range u.NodeDetailsSet {
+			n := &u.NodeDetailsSet[j]
+			if n.GetPlacementUuid() == live.GetUuid() &&
+				oldAZUUIDs[n.GetAzUuid()] && !newAZUUIDs[n.GetAzUuid()] {
+				n.SetState("ToBeRemoved")
+			}
+		}
+		live.PlacementInfo = newCluster.PlacementInfo
+		placementChanged = true
+	}
+	if !editAllowed && !placementChanged {
+		return diags
+	}
+
+	req := readReplicaParams(d.Id(), u, u.Clusters, placementChanged)
+	return append(diags, utils.DispatchAndWait(ctx, "Update Read Replica Cluster", cUUID, c,
+		d.Timeout(schema.TimeoutUpdate), abortOnCancel,
+		utils.ResourceEntity, "Universe Read Replica", "Update",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.UniverseClusterMutationsAPI.UpdateReadOnlyCluster(
+				ctx, cUUID, d.Id()).UniverseConfigureTaskParams(req).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			return r.GetTaskUUID(), resp, nil
+		},
+	)...)
+}
+
+func resourceUniverseReadReplicaDelete(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+
+	clusterUUID := d.Get("cluster_uuid").(string)
+	diags := utils.DispatchAndWait(ctx, "Delete Read Replica Cluster", cUUID, c,
+		d.Timeout(schema.TimeoutDelete), abortOnCancel,
+		utils.ResourceEntity, "Universe Read Replica", "Delete",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.UniverseClusterMutationsAPI.DeleteReadonlyCluster(
+				ctx, cUUID, d.Id(), clusterUUID).IsForceDelete(
+				d.Get("force_delete").(bool)).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			return r.GetTaskUUID(), resp, nil
+		},
+	)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
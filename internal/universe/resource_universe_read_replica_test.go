@@ -0,0 +1,88 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package universe
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+func TestUniverseClusters(t *testing.T) {
+	clusters := []client.Cluster{{ClusterType: "PRIMARY"}, {ClusterType: "ASYNC"}}
+	for _, external := range []bool{false, true} {
+		d := schema.TestResourceDataRaw(t, ResourceUniverse().Schema,
+			map[string]interface{}{"external_read_replica": external})
+		got := universeClusters(d, clusters)
+		want := 2
+		if external {
+			want = 1
+		}
+		if len(got) != want || got[0].ClusterType != "PRIMARY" {
+			t.Errorf("external_read_replica = %v: got %d clusters, want %d", external,
+				len(got), want)
+		}
+	}
+	if len(clusters) != 2 {
+		t.Errorf("universeClusters modified its input")
+	}
+}
+
+func TestBuildReadReplicaCluster(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceUniverseReadReplica().Schema,
+		map[string]interface{}{
+			"universe_uuid": "u1",
+			"user_intent": []interface{}{map[string]interface{}{
+				"provider":           "p1",
+				"region_list":        []interface{}{"r1"},
+				"num_nodes":          3,
+				"replication_factor": 3,
+				"instance_type":      "c5.large",
+				"device_info": []interface{}{map[string]interface{}{
+					"num_volumes":  1,
+					"volume_size":  100,
+					"storage_type": "GP3",
+				}},
+			}},
+		})
+	primary := client.UserIntent{
+		UniverseName:            utils.GetStringPointer("orders"),
+		YbSoftwareVersion:       utils.GetStringPointer("2024.2.0.0-b1"),
+		EnableNodeToNodeEncrypt: utils.GetBoolPointer(true),
+		YsqlPassword:            utils.GetStringPointer("secret"),
+	}
+	cluster := buildReadReplicaCluster(d, primary)
+	ui := cluster.UserIntent
+	if cluster.ClusterType != "ASYNC" {
+		t.Errorf("ClusterType = %q, want ASYNC", cluster.ClusterType)
+	}
+	if ui.GetUniverseName() != "orders" || ui.GetYbSoftwareVersion() != "2024.2.0.0-b1" ||
+		!ui.GetEnableNodeToNodeEncrypt() {
+		t.Errorf("primary settings not inherited: %+v", ui)
+	}
+	if ui.YsqlPassword != nil || ui.GetDedicatedNodes() {
+		t.Errorf("read replica carries primary-only settings: %+v", ui)
+	}
+	if ui.GetInstanceType() != "c5.large" || ui.GetNumNodes() != 3 {
+		t.Errorf("read replica intent not taken from configuration: %+v", ui)
+	}
+	if cluster.PlacementInfo != nil {
+		t.Errorf("PlacementInfo = %+v, want nil without cloud_list", cluster.PlacementInfo)
+	}
+}
@@ -0,0 +1,164 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package universe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+const (
+	// taskRecoveryNone leaves a failed universe task alone: edits are
+	// dispatched on top of whatever it left behind.
+	taskRecoveryNone = "none"
+	// taskRecoveryRetry retries a failed universe task before any edit.
+	taskRecoveryRetry = "retry"
+)
+
+// taskRecoverySchema is the task_recovery block of yba_universe.
+func taskRecoverySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Description: "How to handle a universe whose last YugabyteDB Anywhere task " +
+			"failed (reported in `failed_task_uuid`). A failed task can leave the " +
+			"universe half-applied; further edits are then layered on top of that " +
+			"state.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"mode": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  taskRecoveryNone,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(
+						[]string{taskRecoveryNone, taskRecoveryRetry}, false)),
+					Description: "Allowed values: none, retry. With retry, a plan " +
+						"against a universe whose last task failed shows an update, and " +
+						"the apply retries the failed task through YugabyteDB Anywhere " +
+						"and waits for it before making any other change. The apply " +
+						"fails if YugabyteDB Anywhere does not allow the task to be " +
+						"retried. Defaults to none, which dispatches edits without " +
+						"retrying.",
+				},
+			},
+		},
+	}
+}
+
+// failedTaskUUIDSchema is the computed failed_task_uuid attribute.
+func failedTaskUUIDSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+		Description: "UUID of the last YugabyteDB Anywhere task on the universe when " +
+			"that task failed, empty otherwise. See `task_recovery`.",
+	}
+}
+
+// failedTaskUUID returns the universe's last task when it failed: no update is
+// in progress and the last one did not succeed. It returns "" otherwise.
+func failedTaskUUID(details *client.UniverseDefinitionTaskParamsResp) string {
+	if details == nil || details.GetUpdateInProgress() || details.GetUpdateSucceeded() {
+		return ""
+	}
+	return details.GetUpdatingTaskUUID()
+}
+
+// planTaskRecovery marks failed_task_uuid as changing when task_recovery
+// retries and the last task failed, so the plan shows an update even when the
+// configuration is otherwise unchanged.
+func planTaskRecovery(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || d.Get("task_recovery.0.mode").(string) != taskRecoveryRetry {
+		return nil
+	}
+	if d.Get("failed_task_uuid").(string) == "" {
+		return nil
+	}
+	return d.SetNewComputed("failed_task_uuid")
+}
+
+// retryFailedTask retries the universe's failed last task and waits for it,
+// when task_recovery is set to retry. It runs at the start of
+// resourceUniverseUpdate, so the configured edits are applied to the universe
+// the failed task was meant to produce rather than to a half-applied one.
+func retryFailedTask(
+	ctx context.Context,
+	d *schema.ResourceData,
+	c *client.APIClient,
+	cUUID string,
+	abortOnCancel bool,
+) diag.Diagnostics {
+	if d.Get("task_recovery.0.mode").(string) != taskRecoveryRetry {
+		return nil
+	}
+	r, response, err := c.UniverseManagementAPI.GetUniverse(ctx, cUUID, d.Id()).Execute()
+	if err != nil {
+		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"Universe", "Update - Fetch universe for task recovery")
+		return diag.FromErr(errMessage)
+	}
+	taskUUID := failedTaskUUID(r.UniverseDetails)
+	if taskUUID == "" {
+		return nil
+	}
+	if r.UniverseDetails.GetUniversePaused() {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary: fmt.Sprintf("Universe %s is paused: failed task %s not retried",
+				d.Id(), taskUUID),
+			Detail: "Set paused = false to resume the universe; the task is retried on " +
+				"the next apply.",
+		}}
+	}
+
+	task, response, err := c.CustomerTasksAPI.TaskStatus(ctx, cUUID, taskUUID).Execute()
+	if err != nil {
+		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"Universe", "Update - Fetch failed task")
+		return diag.FromErr(errMessage)
+	}
+	title, _ := task["title"].(string)
+	if retryable, _ := task["retryable"].(bool); !retryable {
+		return diag.Errorf("Universe %s: last task %s (%s) failed and YugabyteDB Anywhere "+
+			"does not allow it to be retried. Resolve it in YugabyteDB Anywhere, or set "+
+			"task_recovery { mode = \"none\" } to apply changes on top of the failed task.",
+			d.Id(), taskUUID, title)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Universe %s: retrying failed task %s (%s)",
+		d.Id(), taskUUID, title))
+	return utils.DispatchAndWait(ctx, "Retry Universe Task", cUUID, c,
+		d.Timeout(schema.TimeoutUpdate), abortOnCancel,
+		utils.ResourceEntity, "Universe", "Update - Retry task",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.CustomerTasksAPI.RetryTask(ctx, cUUID, taskUUID).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			return r.GetTaskUUID(), resp, nil
+		},
+	)
+}
@@ -0,0 +1,47 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package universe
+
+import (
+	"testing"
+
+	client "github.com/yugabyte/platform-go-client"
+)
+
+func TestFailedTaskUUID(t *testing.T) {
+	details := func(inProgress, succeeded bool, task string) *client.UniverseDefinitionTaskParamsResp {
+		d := &client.UniverseDefinitionTaskParamsResp{}
+		d.SetUpdateInProgress(inProgress)
+		d.SetUpdateSucceeded(succeeded)
+		d.SetUpdatingTaskUUID(task)
+		return d
+	}
+	cases := []struct {
+		name    string
+		details *client.UniverseDefinitionTaskParamsResp
+		want    string
+	}{
+		{"nil details", nil, ""},
+		{"last task succeeded", details(false, true, "t1"), ""},
+		{"task running", details(true, false, "t1"), ""},
+		{"last task failed", details(false, false, "t1"), "t1"},
+	}
+	for _, tc := range cases {
+		if got := failedTaskUUID(tc.details); got != tc.want {
+			t.Errorf("%s: failedTaskUUID = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
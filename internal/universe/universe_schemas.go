@@ -380,10 +380,11 @@ func userIntentSchema() *schema.Resource {
 					"for the configured arch.",
 			},
 			"instance_tags": {
-				Type:        schema.TypeMap,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-				Optional:    true,
-				Description: "Instance Tags.",
+				Type:     schema.TypeMap,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+				Description: "Instance Tags. The provider's default_tags are added to them on " +
+					"the nodes; see instance_tags_all.",
 			},
 			"preferred_region": {
 				Type:        schema.TypeString,
@@ -407,16 +408,20 @@ func userIntentSchema() *schema.Resource {
 				Type:      schema.TypeString,
 				Optional:  true,
 				Sensitive: true,
-				Description: "YSQL auth password. Required when enable_ysql_auth is true. " +
-					"Stored in Terraform state - use an encrypted backend for security.",
+				Description: "YSQL auth password. Required when enable_ysql_auth is true, " +
+					"unless ysql_password_wo is set. Changing it on an existing universe " +
+					"resets the superuser password. Stored in Terraform state - use an " +
+					"encrypted backend, or ysql_password_wo to keep it out of state.",
 			},
 			"ycql_password": {
 				Type:      schema.TypeString,
 				Optional:  true,
 				Default:   "",
 				Sensitive: true,
-				Description: "YCQL auth password. Required when enable_ycql_auth is true. " +
-					"Stored in Terraform state - use an encrypted backend for security.",
+				Description: "YCQL auth password. Required when enable_ycql_auth is true, " +
+					"unless ycql_password_wo is set. Changing it on an existing universe " +
+					"resets the superuser password. Stored in Terraform state - use an " +
+					"encrypted backend, or ycql_password_wo to keep it out of state.",
 			},
 			"universe_name": {
 				Type:        schema.TypeString,
@@ -662,6 +667,8 @@ func userIntentSchema() *schema.Resource {
 					},
 				},
 			},
+			"kubernetes":         kubernetesSchema(),
+			"connection_pooling": connectionPoolingSchema(),
 			"dedicated_masters": {
 				Type:     schema.TypeList,
 				MaxItems: 1,
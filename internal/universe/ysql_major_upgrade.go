@@ -0,0 +1,264 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package universe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/api"
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+// First DB versions whose YSQL is based on PostgreSQL 15, per release track.
+// Stable versions are numbered by year, preview versions start with 2.
+const (
+	ysqlPG15StableVersion  = "2025.1.0.0"
+	ysqlPG15PreviewVersion = "2.25.0.0"
+)
+
+// Values of ysql_major_upgrade_precheck.status.
+const (
+	precheckPassed = "Passed"
+	precheckFailed = "Failed"
+)
+
+// ysqlMajorUpgradePrecheckSchema holds the result of the last YSQL major
+// upgrade precheck the provider ran.
+func ysqlMajorUpgradePrecheckSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Description: "Result of the last YSQL major version upgrade precheck. An upgrade " +
+			"of yb_software_version from a PostgreSQL 11 based version to a PostgreSQL 15 " +
+			"based one (2025.1.0.0, preview 2.25.0.0, or later) first runs the YugabyteDB " +
+			"Anywhere precheck task, and only upgrades when it passes.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"target_version": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "DB version the precheck ran for.",
+				},
+				"status": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Outcome of the precheck: Passed or Failed.",
+				},
+				"task_uuid": {
+					Type:     schema.TypeString,
+					Computed: true,
+					Description: "UUID of the precheck task. Its details are in the " +
+						"YugabyteDB Anywhere task list.",
+				},
+				"message": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Error reported by a failed precheck.",
+				},
+			},
+		},
+	}
+}
+
+// isPG15Version reports whether YSQL of DB version v is based on PostgreSQL 15.
+func isPG15Version(v string) (bool, error) {
+	major, err := strconv.Atoi(strings.SplitN(v, ".", 2)[0])
+	if err != nil {
+		return false, fmt.Errorf("invalid DB version %q: %w", v, err)
+	}
+	threshold := ysqlPG15PreviewVersion
+	if major >= 2000 {
+		threshold = ysqlPG15StableVersion
+	}
+	compare, err := utils.CompareYbVersions(v, threshold)
+	if err != nil {
+		return false, err
+	}
+	return compare >= 0, nil
+}
+
+// isYsqlMajorUpgrade reports whether upgrading from oldVersion to newVersion
+// crosses the PostgreSQL 11 to 15 boundary, which needs a YSQL catalog
+// upgrade. Versions that do not parse are left to YBA to reject.
+func isYsqlMajorUpgrade(oldVersion, newVersion string) bool {
+	if oldVersion == "" || newVersion == "" {
+		return false
+	}
+	oldPG15, err := isPG15Version(oldVersion)
+	if err != nil {
+		return false
+	}
+	newPG15, err := isPG15Version(newVersion)
+	if err != nil {
+		return false
+	}
+	return !oldPG15 && newPG15
+}
+
+// primarySoftwareVersion returns yb_software_version of the PRIMARY cluster in
+// a clusters list of the schema.
+func primarySoftwareVersion(clusters []interface{}) string {
+	for _, raw := range clusters {
+		cl, _ := raw.(map[string]interface{})
+		if cl == nil || cl["cluster_type"] != "PRIMARY" {
+			continue
+		}
+		ui := utils.MapFromSingletonList(cl["user_intent"].([]interface{}))
+		v, _ := ui["yb_software_version"].(string)
+		return v
+	}
+	return ""
+}
+
+// validateYsqlMajorUpgrade runs at plan time when the PRIMARY cluster's
+// yb_software_version crosses the YSQL major version boundary. The catalog
+// upgrade needs a monitoring phase before it is finalized, and YBA's software
+// upgrade precheck must accept the target version.
+func validateYsqlMajorUpgrade(
+	ctx context.Context,
+	d *schema.ResourceDiff,
+	meta interface{},
+) error {
+	if d.Id() == "" || !d.HasChange("clusters") {
+		return nil
+	}
+	oldRaw, newRaw := d.GetChange("clusters")
+	oldVersion := primarySoftwareVersion(oldRaw.([]interface{}))
+	newVersion := primarySoftwareVersion(newRaw.([]interface{}))
+	if oldVersion == newVersion || !isYsqlMajorUpgrade(oldVersion, newVersion) {
+		return nil
+	}
+	if d.Get("db_version_upgrade_options.0.finalize").(bool) {
+		return fmt.Errorf("upgrading from %s to %s is a YSQL major version upgrade, which "+
+			"cannot be finalized in the same apply: set db_version_upgrade_options.finalize "+
+			"= false, monitor the universe in PreFinalize state, then set it to true",
+			oldVersion, newVersion)
+	}
+	// The apply runs the precheck task and records its result.
+	if err := d.SetNewComputed("ysql_major_upgrade_precheck"); err != nil {
+		return err
+	}
+
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	req := client.SoftwareUpgradeInfoRequest{
+		YbSoftwareVersion: utils.GetStringPointer(newVersion),
+	}
+	_, response, err := c.UniverseUpgradesManagementAPI.PrecheckSoftwareUpgrade(
+		ctx, cUUID, d.Id()).SoftwareUpgradeInfoRequest(req).Execute()
+	if err != nil {
+		// Only a rejection by YBA fails the plan; connectivity errors are
+		// deferred to apply, like the other plan-time checks.
+		if response == nil || response.StatusCode != http.StatusBadRequest {
+			//nolint:nilerr // Plan-time validator: API errors deferred to update.
+			return nil
+		}
+		return fmt.Errorf("YSQL major version upgrade precheck failed: %w",
+			utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+				"Universe", "Plan - YSQL Major Upgrade Precheck"))
+	}
+	return nil
+}
+
+// runYsqlMajorUpgradePrecheck runs the YBA software upgrade task in precheck
+// only mode, which checks that the YSQL catalog can be upgraded to version,
+// and records the outcome in ysql_major_upgrade_precheck.
+func runYsqlMajorUpgradePrecheck(
+	ctx context.Context,
+	c *client.APIClient,
+	cUUID string,
+	d *schema.ResourceData,
+	clusters []client.Cluster,
+	version string,
+	upgradeOption string,
+	sleepAfterMasterMs, sleepAfterTServerMs int32,
+	timeout time.Duration,
+	abortOnCancel bool,
+) diag.Diagnostics {
+	tflog.Info(ctx, "Running YSQL major version upgrade precheck", map[string]interface{}{
+		"universe_uuid":  d.Id(),
+		"target_version": version,
+	})
+	req := client.SoftwareUpgradeParams{
+		YbSoftwareVersion:              version,
+		Clusters:                       clusters,
+		UpgradeOption:                  upgradeOption,
+		UpgradeSystemCatalog:           true,
+		SleepAfterMasterRestartMillis:  sleepAfterMasterMs,
+		SleepAfterTServerRestartMillis: sleepAfterTServerMs,
+	}
+	req.SetRunOnlyPrechecks(true)
+	var taskUUID string
+	diags := utils.DispatchAndWait(ctx, "YSQL Major Upgrade Precheck", cUUID, c, timeout,
+		abortOnCancel, utils.ResourceEntity, "Universe", "Update - YSQL Major Upgrade Precheck",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.UniverseUpgradesManagementAPI.UpgradeDBVersion(
+				ctx, cUUID, d.Id()).SoftwareUpgradeParams(req).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			taskUUID = r.GetTaskUUID()
+			return taskUUID, resp, nil
+		},
+	)
+
+	result := map[string]interface{}{
+		"target_version": version,
+		"status":         precheckPassed,
+		"task_uuid":      taskUUID,
+		"message":        "",
+	}
+	if diags.HasError() {
+		result["status"] = precheckFailed
+		result["message"] = diagnosticsMessage(diags)
+	}
+	if err := d.Set("ysql_major_upgrade_precheck", []interface{}{result}); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if diags.HasError() {
+		return append(diag.FromErr(errors.New("YSQL major version upgrade precheck failed; "+
+			"the DB version was not upgraded. See ysql_major_upgrade_precheck for the "+
+			"result")), diags...)
+	}
+	return nil
+}
+
+// diagnosticsMessage joins the error summaries and details of diags.
+func diagnosticsMessage(diags diag.Diagnostics) string {
+	var parts []string
+	for _, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+		msg := d.Summary
+		if d.Detail != "" {
+			msg += ": " + d.Detail
+		}
+		parts = append(parts, msg)
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,77 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package universe
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestIsYsqlMajorUpgrade(t *testing.T) {
+	tests := []struct {
+		oldVersion string
+		newVersion string
+		want       bool
+	}{
+		{"2024.2.3.0-b116", "2025.1.0.0-b168", true},
+		{"2024.2.3.0-b116", "2024.2.4.0-b89", false},
+		{"2025.1.0.0-b168", "2025.1.1.0-b12", false},
+		{"2.23.1.0-b220", "2.25.0.0-b489", true},
+		{"2.20.9.0-b47", "2024.2.3.0-b116", false},
+		{"2.20.9.0-b47", "2025.1.0.0-b168", true},
+		{"2025.1.0.0-b168", "2024.2.3.0-b116", false},
+		{"", "2025.1.0.0-b168", false},
+		{"not-a-version", "2025.1.0.0-b168", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.oldVersion+"->"+tt.newVersion, func(t *testing.T) {
+			if got := isYsqlMajorUpgrade(tt.oldVersion, tt.newVersion); got != tt.want {
+				t.Errorf("isYsqlMajorUpgrade() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrimarySoftwareVersion(t *testing.T) {
+	cluster := func(clusterType, version string) interface{} {
+		return map[string]interface{}{
+			"cluster_type": clusterType,
+			"user_intent": []interface{}{map[string]interface{}{
+				"yb_software_version": version,
+			}},
+		}
+	}
+	clusters := []interface{}{cluster("ASYNC", "2.20.9.0-b47"), cluster("PRIMARY", "2025.1.0.0-b168")}
+	if got := primarySoftwareVersion(clusters); got != "2025.1.0.0-b168" {
+		t.Errorf("primarySoftwareVersion() = %q", got)
+	}
+	if got := primarySoftwareVersion(nil); got != "" {
+		t.Errorf("primarySoftwareVersion(nil) = %q, want empty", got)
+	}
+}
+
+func TestDiagnosticsMessage(t *testing.T) {
+	diags := diag.Diagnostics{
+		{Severity: diag.Warning, Summary: "ignored"},
+		{Severity: diag.Error, Summary: "Task failed", Detail: "pg_upgrade --check failed"},
+		{Severity: diag.Error, Summary: "Timeout"},
+	}
+	want := "Task failed: pg_upgrade --check failed; Timeout"
+	if got := diagnosticsMessage(diags); got != want {
+		t.Errorf("diagnosticsMessage() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,184 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	client "github.com/yugabyte/platform-go-client"
+)
+
+// FailedSubtask is one failed step of a YBA task, as reported by
+// CustomerTasksAPI.ListFailedSubtasks.
+type FailedSubtask struct {
+	UUID      string
+	Type      string
+	GroupType string
+	Error     string
+}
+
+// TaskError is the error WaitForTask returns when a task does not reach
+// Success. Callers that need more than the message use errors.As to get the
+// task and the subtasks that failed.
+type TaskError struct {
+	TaskUUID string
+	Title    string
+	// State is the last state seen; a pending state when the wait itself
+	// timed out or was cancelled.
+	State string
+	// FailedSubtasks is empty when the task did not fail, or when the YBA
+	// version cannot list them.
+	FailedSubtasks []FailedSubtask
+	// URL is the task's page in the YugabyteDB Anywhere UI.
+	URL string
+	// Err is why the wait ended: an unexpected final state, a timeout, a
+	// cancelled context or a failed status request.
+	Err error
+}
+
+func (e *TaskError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "task %s", e.TaskUUID)
+	if e.Title != "" {
+		fmt.Fprintf(&b, " (%q)", e.Title)
+	}
+	if e.State != "" {
+		fmt.Fprintf(&b, " State: %s", e.State)
+	}
+	if len(e.FailedSubtasks) > 0 {
+		b.WriteString(",")
+		for _, f := range e.FailedSubtasks {
+			fmt.Fprintf(&b, " SubTaskType: %q, Error: %q;", f.Type, f.Error)
+		}
+	} else if e.Err != nil && !e.ended() {
+		fmt.Fprintf(&b, ": %v", e.Err)
+	}
+	if e.URL != "" {
+		fmt.Fprintf(&b, " See %s", e.URL)
+	}
+	return b.String()
+}
+
+// ended reports whether the task reached a final state, as opposed to the
+// wait stopping first.
+func (e *TaskError) ended() bool {
+	return e.State != "" && !slices.Contains(PendingTaskStates, e.State)
+}
+
+// Unwrap returns the error that ended the wait.
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+// detail renders the failed subtasks one per line, followed by the link to
+// the task.
+func (e *TaskError) detail() string {
+	var b strings.Builder
+	if e.Err != nil && !e.ended() {
+		fmt.Fprintf(&b, "%v\n", e.Err)
+	}
+	for _, f := range e.FailedSubtasks {
+		if f.GroupType != "" {
+			fmt.Fprintf(&b, "- %s (%s): %s\n", f.Type, f.GroupType, f.Error)
+		} else {
+			fmt.Fprintf(&b, "- %s: %s\n", f.Type, f.Error)
+		}
+	}
+	if e.URL != "" {
+		fmt.Fprintf(&b, "See %s for the full task log.", e.URL)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// TaskURL returns the page of taskUUID in the YugabyteDB Anywhere UI served
+// by the host c is configured for.
+func TaskURL(c *client.APIClient, taskUUID string) string {
+	cfg := c.GetConfig()
+	if cfg == nil || cfg.Host == "" {
+		return ""
+	}
+	scheme := cfg.Scheme
+	if scheme == "" {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s/tasks/%s", scheme, cfg.Host, taskUUID)
+}
+
+// failedSubtasks lists the failed subtasks of tUUID. It returns nil, after a
+// warning, when the YBA version cannot list them or the request fails: the
+// caller still reports the task and its link.
+func failedSubtasks(
+	ctx context.Context, cUUID, tUUID string, c *client.APIClient,
+) []FailedSubtask {
+	allowed, _, err := failureSubTaskListYBAVersionCheck(ctx, c)
+	if err != nil || !allowed {
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Could not check YBA version to list failed "+
+				"subtasks of task %s: %v", tUUID, err))
+		}
+		return nil
+	}
+	r, response, err := c.CustomerTasksAPI.ListFailedSubtasks(ctx, cUUID, tUUID).Execute()
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Could not list failed subtasks of task %s: %v", tUUID,
+			ErrorFromHTTPResponse(response, err, "Task", "ListFailedSubtasks",
+				"Get Failed Tasks")))
+		return nil
+	}
+	var subtasks []FailedSubtask
+	for _, f := range r.GetFailedSubTasks() {
+		subtasks = append(subtasks, FailedSubtask{
+			UUID:      f.GetSubTaskUUID(),
+			Type:      f.GetSubTaskType(),
+			GroupType: f.GetSubTaskGroupType(),
+			Error:     f.GetErrorString(),
+		})
+		tflog.Error(ctx, "Subtask failed", map[string]interface{}{
+			"task_uuid":          tUUID,
+			"subtask_uuid":       f.GetSubTaskUUID(),
+			"subtask_type":       f.GetSubTaskType(),
+			"subtask_group_type": f.GetSubTaskGroupType(),
+			"error":              f.GetErrorString(),
+		})
+	}
+	return subtasks
+}
+
+// taskErrorDiagnostics turns an error from WaitForTask into a diagnostic with
+// the failed subtasks and the task link in its detail.
+func taskErrorDiagnostics(label string, err error) diag.Diagnostics {
+	var taskErr *TaskError
+	if !errors.As(err, &taskErr) {
+		return diag.FromErr(err)
+	}
+	summary := fmt.Sprintf("%s: task %s", label, taskErr.TaskUUID)
+	if taskErr.ended() {
+		summary = fmt.Sprintf("%s ended in state %s", summary, taskErr.State)
+	} else {
+		summary += " did not complete"
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail:   taskErr.detail(),
+	}}
+}
@@ -0,0 +1,244 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/yugabyte/platform-go-client"
+)
+
+// PendingTaskUUIDKey is the attribute in which a resource records the YBA task
+// it is waiting on.
+//
+// The SDK gives ResourceData no access to private state, so the UUID is kept
+// in a computed attribute instead. It is empty except when an apply was
+// interrupted mid-task: the attribute then survives in state and the next
+// refresh or apply waits for that task instead of dispatching the change a
+// second time.
+const PendingTaskUUIDKey = "pending_task_uuid"
+
+// PendingTaskUUIDSchema is the schema of PendingTaskUUIDKey.
+func PendingTaskUUIDSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+		Description: "UUID of the YugabyteDB Anywhere task this resource was waiting on " +
+			"when an apply was interrupted. The next refresh or apply waits for the task " +
+			"instead of dispatching the change again. Empty when no task is pending.",
+	}
+}
+
+type pendingTaskContextKey struct{}
+
+// TrackPendingTask returns a context under which DispatchAndWait records the
+// task it waits on in d's pending_task_uuid. d's schema must include
+// PendingTaskUUIDSchema.
+func TrackPendingTask(ctx context.Context, d *schema.ResourceData) context.Context {
+	return context.WithValue(ctx, pendingTaskContextKey{}, d)
+}
+
+// PendingTaskUUID returns the task recorded in d, or "" if none is pending.
+func PendingTaskUUID(d *schema.ResourceData) string {
+	v, _ := d.Get(PendingTaskUUIDKey).(string)
+	return v
+}
+
+func trackedResource(ctx context.Context) *schema.ResourceData {
+	d, _ := ctx.Value(pendingTaskContextKey{}).(*schema.ResourceData)
+	return d
+}
+
+func setPendingTask(ctx context.Context, d *schema.ResourceData, taskUUID string) {
+	if err := d.Set(PendingTaskUUIDKey, taskUUID); err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Could not record pending task %q: %v", taskUUID, err))
+	}
+}
+
+// taskState returns the status of taskUUID and whether YBA can abort it. It
+// uses its own short deadline so it works after ctx was cancelled.
+func taskState(ctx context.Context, cUUID, taskUUID string, c *client.APIClient) (
+	status string, abortable bool, response *http.Response, err error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+	defer cancel()
+	r, response, err := c.CustomerTasksAPI.TaskStatus(ctx, cUUID, taskUUID).Execute()
+	if response != nil {
+		_ = response.Body.Close()
+	}
+	if err != nil {
+		return "", false, response, err
+	}
+	status, _ = r["status"].(string)
+	abortable, _ = r["abortable"].(bool)
+	return status, abortable, response, nil
+}
+
+// taskStillRunning reports whether taskUUID is in one of PendingTaskStates.
+func taskStillRunning(ctx context.Context, cUUID, taskUUID string, c *client.APIClient) bool {
+	status, _, response, err := taskState(ctx, cUUID, taskUUID, c)
+	if err != nil {
+		// A 4xx means YBA no longer knows the task. Anything else leaves the
+		// state unknown: keep the task so the next run checks again.
+		return response == nil || response.StatusCode >= http.StatusInternalServerError
+	}
+	return slices.Contains(PendingTaskStates, status)
+}
+
+// interrupted reports whether ctx ended because Terraform was interrupted.
+// SDKv2 also puts the resource timeout on ctx; a deadline is not an interrupt.
+func interrupted(ctx context.Context) bool {
+	return errors.Is(ctx.Err(), context.Canceled)
+}
+
+// AbortWaitTimeout bounds how long an interrupted apply waits for an aborted
+// task to stop. YBA aborts at the next subtask boundary, which can take a
+// while for subtasks such as a node rolling restart.
+const AbortWaitTimeout = 10 * time.Minute
+
+// abortPollInterval is the wait between status checks of an aborted task.
+var abortPollInterval = 5 * time.Second
+
+// abortInterruptedTask aborts taskUUID after ctx was cancelled and waits for
+// it to stop. handled is false when the task is not abortable or the abort
+// could not be sent; the task then keeps running and the caller records it
+// as pending.
+func abortInterruptedTask(
+	ctx context.Context, label, cUUID, taskUUID string, c *client.APIClient,
+) (diags diag.Diagnostics, handled bool) {
+	status, abortable, _, err := taskState(ctx, cUUID, taskUUID, c)
+	if err != nil || !slices.Contains(PendingTaskStates, status) {
+		return nil, false
+	}
+	if !abortable {
+		tflog.Warn(ctx, fmt.Sprintf("%s: task %s cannot be aborted and keeps running "+
+			"in YugabyteDB Anywhere", label, taskUUID))
+		return nil, false
+	}
+
+	abortCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), AbortWaitTimeout)
+	defer cancel()
+	tflog.Warn(ctx, fmt.Sprintf("%s: interrupted, aborting task %s", label, taskUUID))
+	_, response, err := c.CustomerTasksAPI.AbortTask(abortCtx, cUUID, taskUUID).Execute()
+	if response != nil {
+		_ = response.Body.Close()
+	}
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("%s: could not abort task %s: %v", label, taskUUID,
+			ErrorFromHTTPResponse(response, err, "Task", "AbortTask", "Abort Task")))
+		return nil, false
+	}
+
+	for slices.Contains(PendingTaskStates, status) {
+		select {
+		case <-abortCtx.Done():
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("%s: interrupted, task %s is still aborting", label, taskUUID),
+				Detail: fmt.Sprintf("Terraform was interrupted and abort_tasks_on_cancel is "+
+					"set. The abort of task %s was accepted but the task had not stopped "+
+					"after %s; check the task in YugabyteDB Anywhere before running "+
+					"terraform apply again.", taskUUID, AbortWaitTimeout),
+			}}, true
+		case <-time.After(abortPollInterval):
+		}
+		if status, _, _, err = taskState(abortCtx, cUUID, taskUUID, c); err != nil {
+			status = "Running"
+		}
+	}
+	if status == "Success" {
+		// The task finished before the abort took effect.
+		return nil, true
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("%s: interrupted, task %s aborted", label, taskUUID),
+		Detail: fmt.Sprintf("Terraform was interrupted and abort_tasks_on_cancel is set, "+
+			"so YugabyteDB Anywhere task %s was aborted and ended in state %s. The "+
+			"resource may be partially changed; run terraform apply again to bring it to "+
+			"the configured state.", taskUUID, status),
+	}}, true
+}
+
+// interruptedTaskDiagnostics reports a wait that ended while the task was
+// still running. A Create cancelled by an interrupt gets a warning: an error
+// would taint the new resource, and the next apply would replace it instead
+// of waiting. A timeout stays an error, since the apply carries on and
+// dependent resources must not start against an unfinished one.
+func interruptedTaskDiagnostics(
+	ctx context.Context, d *schema.ResourceData, label, taskUUID string, err error,
+) diag.Diagnostics {
+	severity := diag.Error
+	if d.IsNewResource() && interrupted(ctx) {
+		severity = diag.Warning
+	}
+	return diag.Diagnostics{{
+		Severity: severity,
+		Summary:  fmt.Sprintf("%s: stopped waiting for task %s", label, taskUUID),
+		Detail: fmt.Sprintf("%v. The task is still running in YugabyteDB Anywhere and "+
+			"is recorded in %s; the next refresh or apply waits for it instead of "+
+			"dispatching it again.", err, PendingTaskUUIDKey),
+	}}
+}
+
+// ResumePendingTask waits for the task recorded in d's pending_task_uuid by
+// an interrupted apply, then clears it. Resources call it at the start of
+// Read and Update, before looking at YBA.
+//
+// A pending task that failed or no longer exists is reported as a warning:
+// the refresh that follows shows what it left behind, and the plan puts back
+// whatever it did not do.
+func ResumePendingTask(
+	ctx context.Context,
+	d *schema.ResourceData,
+	cUUID string,
+	c *client.APIClient,
+	timeout time.Duration,
+	abortOnCancel bool,
+) diag.Diagnostics {
+	taskUUID := PendingTaskUUID(d)
+	if taskUUID == "" || ctx.Err() != nil {
+		return nil
+	}
+	tflog.Info(ctx, fmt.Sprintf(
+		"Re-attaching to task %s left running by an interrupted apply", taskUUID))
+	err := WaitForTask(ctx, taskUUID, cUUID, c, timeout)
+	if err != nil && abortOnCancel && interrupted(ctx) {
+		if diags, handled := abortInterruptedTask(ctx, "Resume", cUUID, taskUUID, c); handled {
+			setPendingTask(ctx, d, "")
+			return diags
+		}
+	}
+	if err != nil && taskStillRunning(ctx, cUUID, taskUUID, c) {
+		return interruptedTaskDiagnostics(ctx, d, "Resume", taskUUID, err)
+	}
+	setPendingTask(ctx, d, "")
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Task %s from an interrupted apply did not succeed", taskUUID),
+			Detail:   err.Error(),
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/yugabyte/platform-go-client"
+)
+
+// fakeTaskYBA serves TaskStatus for one task whose status the test controls,
+// and counts dispatches.
+type fakeTaskYBA struct {
+	mu         sync.Mutex
+	version    string
+	status     string
+	dispatches int
+	aborts     int
+}
+
+func (f *fakeTaskYBA) setStatus(s string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.status = s
+}
+
+func (f *fakeTaskYBA) handler(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.URL.Path == "/api/v1/app_version":
+		version := f.version
+		if version == "" {
+			version = "2.14.0.0-b1"
+		}
+		_, _ = fmt.Fprintf(w, `{"version":%q}`, version)
+	case strings.HasSuffix(r.URL.Path, "/tasks/task-1/failed_subtasks"):
+		_, _ = w.Write([]byte(`{"failedSubTasks":[{"subTaskUUID":"sub-1",` +
+			`"subTaskType":"AnsibleConfigureServers","subTaskGroupType":"ConfigureUniverse",` +
+			`"errorString":"Failed on node yb-n2"}]}`))
+	case strings.HasSuffix(r.URL.Path, "/tasks/task-1/abort"):
+		f.aborts++
+		f.status = "Aborted"
+		_, _ = w.Write([]byte(`{"success":true}`))
+	case strings.HasSuffix(r.URL.Path, "/tasks/task-1"):
+		_, _ = fmt.Fprintf(w, `{"title":"Edit Universe","percent":50,"status":%q,`+
+			`"abortable":true,"details":{"taskDetails":[`+
+			`{"title":"Configuring the universe","state":%q}]}}`, f.status, f.status)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newFakeTaskClient(t *testing.T, f *fakeTaskYBA) *client.APIClient {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(f.handler))
+	t.Cleanup(srv.Close)
+	cfg := client.NewConfiguration()
+	cfg.Scheme = "http"
+	cfg.Host = srv.Listener.Addr().String()
+	return client.NewAPIClient(cfg)
+}
+
+func newPendingTaskData(t *testing.T, pending string) *schema.ResourceData {
+	t.Helper()
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		PendingTaskUUIDKey: PendingTaskUUIDSchema(),
+	}, map[string]interface{}{})
+	d.SetId("res")
+	if err := d.Set(PendingTaskUUIDKey, pending); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestDispatchAndWaitRecordsPendingTask(t *testing.T) {
+	f := &fakeTaskYBA{status: "Running"}
+	c := newFakeTaskClient(t, f)
+	d := newPendingTaskData(t, "")
+
+	// Interrupt the wait while the task is still running.
+	ctx, cancel := context.WithCancel(TrackPendingTask(context.Background(), d))
+	time.AfterFunc(100*time.Millisecond, cancel)
+	diags := DispatchAndWait(ctx, "Edit Universe", "cust", c, time.Minute, false,
+		ResourceEntity, "Universe", "Update",
+		func() (string, *http.Response, error) {
+			f.dispatches++
+			return "task-1", nil, nil
+		})
+	if !diags.HasError() {
+		t.Fatalf("interrupted update: diags = %v, want an error", diags)
+	}
+	if got := PendingTaskUUID(d); got != "task-1" {
+		t.Fatalf("pending task = %q, want task-1 kept for the next run", got)
+	}
+
+	// The next run re-attaches instead of dispatching again.
+	f.setStatus("Success")
+	if diags := ResumePendingTask(context.Background(), d, "cust", c, time.Minute,
+		false); diags != nil {
+		t.Errorf("ResumePendingTask diags = %v, want none", diags)
+	}
+	if got := PendingTaskUUID(d); got != "" {
+		t.Errorf("pending task = %q, want cleared", got)
+	}
+	if f.dispatches != 1 {
+		t.Errorf("dispatches = %d, want 1", f.dispatches)
+	}
+}
+
+func TestResumePendingTaskFailed(t *testing.T) {
+	f := &fakeTaskYBA{status: "Failure"}
+	c := newFakeTaskClient(t, f)
+	d := newPendingTaskData(t, "task-1")
+
+	diags := ResumePendingTask(context.Background(), d, "cust", c, time.Minute, false)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("diags = %v, want one warning", diags)
+	}
+	if got := PendingTaskUUID(d); got != "" {
+		t.Errorf("pending task = %q, want cleared after the task ended", got)
+	}
+}
+
+func TestResumePendingTaskNone(t *testing.T) {
+	d := newPendingTaskData(t, "")
+	// A nil client would panic if ResumePendingTask called YBA.
+	if diags := ResumePendingTask(context.Background(), d, "cust", nil, time.Minute,
+		false); diags != nil {
+		t.Errorf("diags = %v, want none", diags)
+	}
+}
+
+func TestDispatchAndWaitAbortsOnCancel(t *testing.T) {
+	defer func(d time.Duration) { abortPollInterval = d }(abortPollInterval)
+	abortPollInterval = 10 * time.Millisecond
+
+	f := &fakeTaskYBA{status: "Running"}
+	c := newFakeTaskClient(t, f)
+	d := newPendingTaskData(t, "")
+
+	ctx, cancel := context.WithCancel(TrackPendingTask(context.Background(), d))
+	time.AfterFunc(100*time.Millisecond, cancel)
+	diags := DispatchAndWait(ctx, "Edit Universe", "cust", c, time.Minute, true,
+		ResourceEntity, "Universe", "Update",
+		func() (string, *http.Response, error) { return "task-1", nil, nil })
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "aborted") {
+		t.Fatalf("diags = %v, want an error reporting the abort", diags)
+	}
+	if f.aborts != 1 {
+		t.Errorf("aborts = %d, want 1", f.aborts)
+	}
+	if got := PendingTaskUUID(d); got != "" {
+		t.Errorf("pending task = %q, want cleared once the task was aborted", got)
+	}
+}
+
+func TestDispatchAndWaitTimeoutDoesNotAbort(t *testing.T) {
+	f := &fakeTaskYBA{status: "Running"}
+	c := newFakeTaskClient(t, f)
+	d := newPendingTaskData(t, "")
+
+	// The resource timeout expiring is not an interrupt: the task keeps running.
+	ctx, cancel := context.WithTimeout(TrackPendingTask(context.Background(), d),
+		100*time.Millisecond)
+	defer cancel()
+	diags := DispatchAndWait(ctx, "Edit Universe", "cust", c, time.Minute, true,
+		ResourceEntity, "Universe", "Update",
+		func() (string, *http.Response, error) { return "task-1", nil, nil })
+	if !diags.HasError() {
+		t.Fatalf("diags = %v, want an error", diags)
+	}
+	if f.aborts != 0 {
+		t.Errorf("aborts = %d, want 0 after a timeout", f.aborts)
+	}
+	if got := PendingTaskUUID(d); got != "task-1" {
+		t.Errorf("pending task = %q, want task-1 kept for the next run", got)
+	}
+}
+
+func TestInterruptedTaskDiagnostics(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	timedOut, cancel := context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
+	<-timedOut.Done()
+
+	cases := []struct {
+		name     string
+		ctx      context.Context
+		create   bool
+		severity diag.Severity
+	}{
+		{"interrupted create", cancelled, true, diag.Warning},
+		{"timed-out create", timedOut, true, diag.Error},
+		{"interrupted update", cancelled, false, diag.Error},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newPendingTaskData(t, "task-1")
+			if tc.create {
+				d.MarkNewResource()
+			}
+			diags := interruptedTaskDiagnostics(tc.ctx, d, "Create Universe", "task-1",
+				tc.ctx.Err())
+			if len(diags) != 1 || diags[0].Severity != tc.severity {
+				t.Errorf("diags = %v, want one with severity %v", diags, tc.severity)
+			}
+		})
+	}
+}
+
+func TestWaitForTaskError(t *testing.T) {
+	f := &fakeTaskYBA{version: "2.20.1.0-b97", status: "Failure"}
+	c := newFakeTaskClient(t, f)
+
+	err := WaitForTask(context.Background(), "task-1", "cust", c, time.Minute)
+	var taskErr *TaskError
+	if !errors.As(err, &taskErr) {
+		t.Fatalf("err = %v (%T), want a *TaskError", err, err)
+	}
+	if taskErr.TaskUUID != "task-1" || taskErr.Title != "Edit Universe" ||
+		taskErr.State != "Failure" {
+		t.Errorf("task = %q %q %q, want task-1 \"Edit Universe\" Failure",
+			taskErr.TaskUUID, taskErr.Title, taskErr.State)
+	}
+	want := []FailedSubtask{{
+		UUID:      "sub-1",
+		Type:      "AnsibleConfigureServers",
+		GroupType: "ConfigureUniverse",
+		Error:     "Failed on node yb-n2",
+	}}
+	if !reflect.DeepEqual(taskErr.FailedSubtasks, want) {
+		t.Errorf("failed subtasks = %+v, want %+v", taskErr.FailedSubtasks, want)
+	}
+	if !strings.HasSuffix(taskErr.URL, "/tasks/task-1") ||
+		!strings.HasPrefix(taskErr.URL, "http://") {
+		t.Errorf("URL = %q, want the task page on the YBA host", taskErr.URL)
+	}
+
+	diags := taskErrorDiagnostics("Edit Universe", err)
+	if len(diags) != 1 || !strings.Contains(diags[0].Detail, "Failed on node yb-n2") ||
+		!strings.Contains(diags[0].Detail, taskErr.URL) {
+		t.Errorf("diags = %+v, want the failed subtask and the task link", diags)
+	}
+}
@@ -178,9 +178,14 @@ var (
 // minutes, so a short but non-trivial delay avoids hammering the API.
 const TaskConflictRetryDelay = 10 * time.Second
 
-// WaitForTask waits for State change for a YBA task
+// WaitForTask waits for State change for a YBA task. Progress is logged with
+// structured fields: task_uuid, task_title, task_status and percent for the
+// task, and subtask_title and subtask_state each time a subtask changes
+// state. A task that does not reach Success is returned as a *TaskError.
 func WaitForTask(ctx context.Context, tUUID string, cUUID string, c *client.APIClient,
 	timeout time.Duration) error {
+	var title, status string
+	subtaskStates := make(map[string]string)
 	wait := &retry.StateChangeConf{
 		Delay:   1 * time.Second,
 		Pending: PendingTaskStates,
@@ -194,53 +199,51 @@ func WaitForTask(ctx context.Context, tUUID string, cUUID string, c *client.APIC
 					"Get Task Status")
 				return nil, "", errMessage
 			}
-			tflog.Info(
-				ctx,
-				fmt.Sprintf("Task \"%s\" completion percentage: %.0f%%", r["title"].(string),
-					r["percent"].(float64)),
-			)
-
-			subtasksDetailsList := r["details"].(map[string]interface{})["taskDetails"].([]interface{})
-			var subtasksStatus string
+			title, _ = r["title"].(string)
+			status, _ = r["status"].(string)
+			percent, _ := r["percent"].(float64)
+			tflog.Info(ctx, fmt.Sprintf("Task \"%s\" completion percentage: %.0f%%", title,
+				percent), map[string]interface{}{
+				"task_uuid":   tUUID,
+				"task_title":  title,
+				"task_status": status,
+				"percent":     percent,
+			})
+
+			details, _ := r["details"].(map[string]interface{})
+			subtasksDetailsList, _ := details["taskDetails"].([]interface{})
 			for _, task := range subtasksDetailsList {
-				taskMap := task.(map[string]interface{})
-				subtasksStatus = fmt.Sprintf("%sTitle: \"%s\", Status: \"%s\"; ",
-					subtasksStatus, taskMap["title"].(string), taskMap["state"].(string))
+				taskMap, _ := task.(map[string]interface{})
+				subtaskTitle, _ := taskMap["title"].(string)
+				subtaskState, _ := taskMap["state"].(string)
+				if subtaskStates[subtaskTitle] == subtaskState {
+					continue
+				}
+				subtaskStates[subtaskTitle] = subtaskState
+				tflog.Info(ctx, fmt.Sprintf("Subtask \"%s\": %s", subtaskTitle, subtaskState),
+					map[string]interface{}{
+						"task_uuid":     tUUID,
+						"task_title":    title,
+						"subtask_title": subtaskTitle,
+						"subtask_state": subtaskState,
+					})
 			}
-			if subtasksStatus != "" {
-				tflog.Info(ctx, fmt.Sprintf("Substasks: %s", subtasksStatus))
-			}
-			s := r["status"].(string)
-			return s, s, nil
+			return status, status, nil
 		},
 	}
 
-	if funcResponse, err := wait.WaitForStateContext(ctx); err != nil {
-		allowed, _, errV := failureSubTaskListYBAVersionCheck(ctx, c)
-		if errV != nil {
-			return errV
-		}
-		var subtasksFailure string
-		if allowed {
-			r, response, errR := c.CustomerTasksAPI.ListFailedSubtasks(ctx, cUUID, tUUID).Execute()
-			if errR != nil {
-				errMessage := ErrorFromHTTPResponse(response, errR, "Task", "ListFailedSubtasks",
-					"Get Failed Tasks")
-				return errMessage
-			}
-
-			for _, f := range r.GetFailedSubTasks() {
-				subtasksFailure = fmt.Sprintf("%sSubTaskType: \"%s\", Error: \"%s\"; ",
-					subtasksFailure, f.GetSubTaskType(), f.GetErrorString())
-			}
-		} else {
-			subtasksFailure = fmt.Sprintln("Please refer to the YugabyteDB Anywhere Tasks",
-				"for description")
+	if _, err := wait.WaitForStateContext(ctx); err != nil {
+		taskErr := &TaskError{
+			TaskUUID: tUUID,
+			Title:    title,
+			State:    status,
+			URL:      TaskURL(c, tUUID),
+			Err:      err,
 		}
-		if subtasksFailure != "" {
-			return fmt.Errorf("State: %s, %s", funcResponse.(string), subtasksFailure)
+		if taskErr.ended() {
+			taskErr.FailedSubtasks = failedSubtasks(ctx, cUUID, tUUID, c)
 		}
-		return fmt.Errorf("State: %s", funcResponse.(string))
+		return taskErr
 	}
 
 	return nil
@@ -483,7 +486,8 @@ func ErrorFromHTTPResponse(resp *http.Response, apiError error, entity, entityNa
 		return fmt.Errorf("%s: %s, Operation: %s - authentication failed (HTTP 401): "+
 			"the API token is invalid, expired, or missing. "+
 			"Please verify your 'api_token' provider configuration or "+
-			"YBA_API_TOKEN/YBA_API_KEY/YB_API_KEY environment variable",
+			"YBA_API_TOKEN/YBA_API_KEY/YB_API_KEY environment variable "+
+			"(or 'email'/'password' and 'bearer_token' when logging in)",
 			entity, entityName, operation)
 	}
 	if response.StatusCode == http.StatusForbidden {
@@ -889,10 +893,16 @@ func RetryOnUniverseTaskConflict(
 // error. Additional values produced by the API (e.g. a resource UUID) can be captured
 // from within the fn closure before returning.
 //
+// Under a context from TrackPendingTask, the task UUID is recorded in the resource's
+// pending_task_uuid while waiting, and kept there if the wait ends with the task still
+// running, so the next run can re-attach with ResumePendingTask. With abortOnCancel,
+// the provider's abort_tasks_on_cancel setting, an interrupt aborts the task instead,
+// if YBA reports it abortable. A timeout never aborts the task.
+//
 // Example -- universe upgrade:
 //
 //	if diags := utils.DispatchAndWait(ctx, "GFlags Upgrade", cUUID, c,
-//	    d.Timeout(schema.TimeoutUpdate),
+//	    d.Timeout(schema.TimeoutUpdate), apiClient.AbortTasksOnCancel,
 //	    utils.ResourceEntity, "Universe", "Update - GFlags",
 //	    func() (string, *http.Response, error) {
 //	        r, resp, err := c.UniverseUpgradesManagementAPI.UpgradeGFlags(
@@ -908,7 +918,7 @@ func RetryOnUniverseTaskConflict(
 //
 //	var resourceUUID string
 //	if diags := utils.DispatchAndWait(ctx, "Create Universe", cUUID, c,
-//	    d.Timeout(schema.TimeoutCreate),
+//	    d.Timeout(schema.TimeoutCreate), apiClient.AbortTasksOnCancel,
 //	    utils.ResourceEntity, "Universe", "Create",
 //	    func() (string, *http.Response, error) {
 //	        r, resp, err := c.UniverseClusterMutationsAPI.CreateAllClusters(ctx, cUUID).
@@ -927,6 +937,7 @@ func DispatchAndWait(
 	cUUID string,
 	c *client.APIClient,
 	timeout time.Duration,
+	abortOnCancel bool,
 	entity, resourceName, operation string,
 	fn func() (taskUUID string, response *http.Response, err error),
 ) diag.Diagnostics {
@@ -972,8 +983,29 @@ func DispatchAndWait(
 
 	tflog.Info(ctx, fmt.Sprintf("%s: task %s dispatched, waiting for completion",
 		label, taskUUID))
+	d := trackedResource(ctx)
+	if d != nil {
+		setPendingTask(ctx, d, taskUUID)
+	}
 	if err := WaitForTask(ctx, taskUUID, cUUID, c, timeout); err != nil {
-		return diag.FromErr(err)
+		if abortOnCancel && interrupted(ctx) {
+			if diags, handled := abortInterruptedTask(ctx, label, cUUID, taskUUID, c); handled {
+				if d != nil {
+					setPendingTask(ctx, d, "")
+				}
+				return diags
+			}
+		}
+		if d != nil && taskStillRunning(ctx, cUUID, taskUUID, c) {
+			return interruptedTaskDiagnostics(ctx, d, label, taskUUID, err)
+		}
+		if d != nil {
+			setPendingTask(ctx, d, "")
+		}
+		return taskErrorDiagnostics(label, err)
+	}
+	if d != nil {
+		setPendingTask(ctx, d, "")
 	}
 	return nil
 }
@@ -1005,6 +1037,13 @@ func CheckMinimumYBAVersion(ctx context.Context, c *client.APIClient) error {
 	return nil
 }
 
+// TriggerFired reports whether the string trigger at key changed to a
+// non-empty value in this update. Setting it for the first time fires;
+// clearing it never does.
+func TriggerFired(d *schema.ResourceData, key string) bool {
+	return d.HasChange(key) && d.Get(key).(string) != ""
+}
+
 // RevertFields resets each named field to its prior state value.
 // Call this before returning an error from an Update function to prevent
 // Terraform from persisting new planned values to state when the API call
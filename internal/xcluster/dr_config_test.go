@@ -0,0 +1,45 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package xcluster
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDatabaseIDs(t *testing.T) {
+	databases := map[string]string{
+		"orders":    "00004000-0000-3000-8000-000000004001",
+		"inventory": "00004000-0000-3000-8000-000000004002",
+	}
+
+	ids, err := resolveDatabaseIDs(databases, []string{"inventory"})
+	if err != nil {
+		t.Fatalf("resolveDatabaseIDs(inventory): %v", err)
+	}
+	if want := []string{databases["inventory"]}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("resolveDatabaseIDs(inventory) = %v, want %v", ids, want)
+	}
+	if _, err = resolveDatabaseIDs(databases, []string{"orders", "missing"}); err == nil {
+		t.Error("resolveDatabaseIDs(missing) succeeded, want an error")
+	}
+
+	// YBA may report namespace IDs without UUID dashes.
+	names := databaseNames(databases, []string{"00004000000030008000000000004001", "gone"})
+	if want := []string{"orders"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("databaseNames() = %v, want %v", names, want)
+	}
+}
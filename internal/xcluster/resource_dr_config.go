@@ -0,0 +1,527 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package xcluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/api"
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+// ResourceDRConfig manages a disaster recovery config
+func ResourceDRConfig() *schema.Resource {
+	return &schema.Resource{
+		Description: "Disaster recovery (DR) config: transactional xCluster replication " +
+			"of YSQL databases from a primary universe to a DR replica universe, with " +
+			"switchover and failover between them.",
+
+		CreateContext: resourceDRConfigCreate,
+		ReadContext:   resourceDRConfigRead,
+		UpdateContext: resourceDRConfigUpdate,
+		DeleteContext: resourceDRConfigDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the DR config. Changing this value recreates the config.",
+			},
+			"primary_universe_uuid": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressSwappedRoles,
+				Description: "UUID of the primary universe. After a switchover or failover, " +
+					"state records the current primary, and the original configuration " +
+					"with the roles swapped shows no diff.",
+			},
+			"dr_replica_universe_uuid": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressSwappedRoles,
+				Description: "UUID of the DR replica universe. Follows the current role " +
+					"like primary_universe_uuid.",
+			},
+			"dbs": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the YSQL databases to replicate. Databases can be " +
+					"added and removed in place; added databases are bootstrapped with " +
+					"bootstrap_params.",
+			},
+			"bootstrap_params": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Description: "Backup used to copy the existing data of the databases to the " +
+					"DR replica before replication starts.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"storage_config_uuid": {
+							Type:     schema.TypeString,
+							Required: true,
+							Description: "UUID of the storage config the bootstrap backup " +
+								"is written to.",
+						},
+						"parallelism": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  8,
+							Description: "Number of concurrent commands run on nodes " +
+								"during the bootstrap backup. Default: 8.",
+						},
+					},
+				},
+			},
+			"switchover_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Changing this to any new non-empty value runs a planned " +
+					"switchover on the next apply: the DR replica becomes the primary and " +
+					"the primary becomes the DR replica, without data loss. Setting it at " +
+					"creation records it without switching over. Removing it never fires.",
+			},
+			"failover_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Changing this to any new non-empty value runs an unplanned " +
+					"failover on the next apply: the DR replica becomes the primary, " +
+					"restored to the latest safe time of each database, and replication " +
+					"halts. Writes after the safe time are lost. Setting it at creation " +
+					"records it without failing over. Removing it never fires.",
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "State of the DR config (e.g., Replicating, Switchover in " +
+					"Progress, Halted).",
+			},
+			"xcluster_config_uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "UUID of the xCluster config that replicates the databases.",
+			},
+		},
+	}
+}
+
+// suppressSwappedRoles hides the role attributes' diff when the configured
+// universes are the state's with primary and DR replica swapped: a switchover
+// or failover swapped them, and the configuration still names the original
+// roles.
+func suppressSwappedRoles(k, old, new string, d *schema.ResourceData) bool {
+	oldPrimary, newPrimary := d.GetChange("primary_universe_uuid")
+	oldReplica, newReplica := d.GetChange("dr_replica_universe_uuid")
+	return oldPrimary.(string) != "" && oldPrimary == newReplica && oldReplica == newPrimary
+}
+
+// normalizeNamespaceID compares namespace IDs with or without UUID dashes.
+func normalizeNamespaceID(id string) string {
+	return strings.ToLower(strings.ReplaceAll(id, "-", ""))
+}
+
+// listYSQLDatabases maps the YSQL database names of a universe to their
+// namespace IDs.
+func listYSQLDatabases(
+	ctx context.Context,
+	c *client.APIClient,
+	cUUID, universeUUID string,
+) (map[string]string, error) {
+	r, response, err := c.TableManagementAPI.GetAllNamespaces(ctx, cUUID, universeUUID).
+		Execute()
+	if err != nil {
+		return nil, utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"DR Config", "List universe databases")
+	}
+	dbs := map[string]string{}
+	for _, ns := range r {
+		if ns.GetTableType() == ysqlTableType {
+			dbs[ns.GetName()] = ns.GetNamespaceUUID()
+		}
+	}
+	return dbs, nil
+}
+
+// resolveDatabaseIDs returns the namespace IDs of the named databases.
+func resolveDatabaseIDs(databases map[string]string, names []string) ([]string, error) {
+	ids := make([]string, 0, len(names))
+	var missing []string
+	for _, name := range names {
+		id, ok := databases[name]
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("YSQL databases not found on the primary universe: %s",
+			strings.Join(missing, ", "))
+	}
+	return ids, nil
+}
+
+// databaseNames maps namespace IDs back to database names. IDs the universe
+// no longer lists are ignored.
+func databaseNames(databases map[string]string, ids []string) []string {
+	byID := make(map[string]string, len(databases))
+	for name, id := range databases {
+		byID[normalizeNamespaceID(id)] = name
+	}
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if name, ok := byID[normalizeNamespaceID(id)]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// drBootstrapParams builds the bootstrap request from bootstrap_params.
+func drBootstrapParams(d *schema.ResourceData) client.RestartBootstrapParams {
+	block := d.Get("bootstrap_params").([]interface{})[0].(map[string]interface{})
+	return client.RestartBootstrapParams{
+		BackupRequestParams: client.BootstrapBackupParams{
+			StorageConfigUUID: block["storage_config_uuid"].(string),
+			Parallelism:       utils.GetInt32Pointer(int32(block["parallelism"].(int))),
+		},
+	}
+}
+
+// configuredDatabaseIDs resolves dbs on the current primary universe.
+func configuredDatabaseIDs(
+	ctx context.Context,
+	d *schema.ResourceData,
+	c *client.APIClient,
+	cUUID string,
+) ([]string, error) {
+	databases, err := listYSQLDatabases(ctx, c, cUUID, d.Get("primary_universe_uuid").(string))
+	if err != nil {
+		return nil, err
+	}
+	return resolveDatabaseIDs(databases,
+		*utils.StringSlice(d.Get("dbs").(*schema.Set).List()))
+}
+
+func resourceDRConfigCreate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+
+	dbIDs, err := configuredDatabaseIDs(ctx, d, c, cUUID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req := client.DrConfigCreateForm{
+		Name:               d.Get("name").(string),
+		SourceUniverseUUID: d.Get("primary_universe_uuid").(string),
+		TargetUniverseUUID: d.Get("dr_replica_universe_uuid").(string),
+		Dbs:                dbIDs,
+		BootstrapParams:    drBootstrapParams(d),
+	}
+
+	tflog.Info(ctx, "Creating DR config", map[string]interface{}{
+		"name":                     req.Name,
+		"primary_universe_uuid":    req.SourceUniverseUUID,
+		"dr_replica_universe_uuid": req.TargetUniverseUUID,
+	})
+	if diags := utils.DispatchAndWait(ctx, "Create DR Config", cUUID, c,
+		d.Timeout(schema.TimeoutCreate), abortOnCancel,
+		utils.ResourceEntity, "DR Config", "Create",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.DisasterRecoveryAPI.CreateDisasterRecoveryConfig(ctx, cUUID).
+				DisasterRecoveryCreateFormData(req).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			d.SetId(r.GetResourceUUID())
+			return r.GetTaskUUID(), resp, nil
+		},
+	); diags != nil {
+		return diags
+	}
+	return resourceDRConfigRead(ctx, d, meta)
+}
+
+func resourceDRConfigRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+
+	r, response, err := c.DisasterRecoveryAPI.GetDisasterRecoveryConfig(ctx, cUUID, d.Id()).
+		Execute()
+	if err != nil {
+		if utils.IsHTTPNotFound(response) || utils.IsHTTPBadRequestNotFound(response) {
+			tflog.Warn(ctx, fmt.Sprintf("DR config %s not found, removing from state: %v",
+				d.Id(), err))
+			d.SetId("")
+			return nil
+		}
+		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"DR Config", "Read")
+		return diag.FromErr(errMessage)
+	}
+
+	if err = d.Set("name", r.GetName()); err != nil {
+		return diag.FromErr(err)
+	}
+	// The roles follow YBA, which swaps them on switchover and failover.
+	if err = d.Set("primary_universe_uuid", r.GetPrimaryUniverseUuid()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("dr_replica_universe_uuid", r.GetDrReplicaUniverseUuid()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("state", r.GetState()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("xcluster_config_uuid", r.GetXclusterConfigUuid()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The primary universe cannot list its databases while it is paused or
+	// down after a failover; the configured ones are kept.
+	databases, err := listYSQLDatabases(ctx, c, cUUID, r.GetPrimaryUniverseUuid())
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Skipping database refresh of DR config %s: %v",
+			d.Id(), err))
+		return nil
+	}
+	if err = d.Set("dbs", databaseNames(databases, r.GetDbs())); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceDRConfigUpdate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) (diags diag.Diagnostics) {
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	defer func() {
+		diags = append(resourceDRConfigRead(ctx, d, meta), diags...)
+	}()
+
+	switchover := utils.TriggerFired(d, "switchover_trigger")
+	failover := utils.TriggerFired(d, "failover_trigger")
+	if switchover && failover {
+		// Keep the previous triggers so that either one can fire next time.
+		utils.RevertFields(d, "switchover_trigger", "failover_trigger")
+		return diag.Errorf("switchover_trigger and failover_trigger cannot fire in the " +
+			"same apply")
+	}
+
+	// Databases are set on the current primary, before the roles change.
+	if d.HasChange("dbs") {
+		dbIDs, err := configuredDatabaseIDs(ctx, d, c, cUUID)
+		if err != nil {
+			utils.RevertFields(d, "dbs", "switchover_trigger", "failover_trigger")
+			return diag.FromErr(err)
+		}
+		bootstrap := drBootstrapParams(d)
+		req := client.DrConfigSetDatabasesForm{
+			Dbs:             dbIDs,
+			BootstrapParams: &bootstrap,
+		}
+		if diags = utils.DispatchAndWait(ctx, "Set DR Config Databases", cUUID, c, timeout,
+			abortOnCancel, utils.ResourceEntity, "DR Config", "Update - Set Databases",
+			func() (string, *http.Response, error) {
+				r, resp, err := c.DisasterRecoveryAPI.SetDatabasesDisasterRecovery(
+					ctx, cUUID, d.Id()).DisasterRecoverySetDatabasesFormData(req).Execute()
+				if err != nil {
+					return "", resp, err
+				}
+				return r.GetTaskUUID(), resp, nil
+			},
+		); diags.HasError() {
+			utils.RevertFields(d, "dbs", "switchover_trigger", "failover_trigger")
+			return diags
+		}
+	}
+
+	switch {
+	case switchover:
+		diags = append(diags, switchoverDRConfig(ctx, d, c, cUUID, timeout, abortOnCancel)...)
+	case failover:
+		diags = append(diags, failoverDRConfig(ctx, d, c, cUUID, timeout, abortOnCancel)...)
+	}
+	if diags.HasError() {
+		// A trigger that did not take effect fires again on the next apply.
+		utils.RevertFields(d, "switchover_trigger", "failover_trigger")
+	}
+	return diags
+}
+
+// liveRoles returns the current primary and DR replica of the config. State
+// can lag behind when an earlier apply was interrupted.
+func liveRoles(
+	ctx context.Context,
+	d *schema.ResourceData,
+	c *client.APIClient,
+	cUUID string,
+) (string, string, diag.Diagnostics) {
+	r, response, err := c.DisasterRecoveryAPI.GetDisasterRecoveryConfig(ctx, cUUID, d.Id()).
+		Execute()
+	if err != nil {
+		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"DR Config", "Update - Fetch DR config")
+		return "", "", diag.FromErr(errMessage)
+	}
+	return r.GetPrimaryUniverseUuid(), r.GetDrReplicaUniverseUuid(), nil
+}
+
+// switchoverDRConfig makes the DR replica the primary and the primary the DR
+// replica.
+func switchoverDRConfig(
+	ctx context.Context,
+	d *schema.ResourceData,
+	c *client.APIClient,
+	cUUID string,
+	timeout time.Duration,
+	abortOnCancel bool,
+) diag.Diagnostics {
+	primary, replica, diags := liveRoles(ctx, d, c, cUUID)
+	if diags != nil {
+		return diags
+	}
+	req := client.DrConfigSwitchoverForm{
+		PrimaryUniverseUuid:   replica,
+		DrReplicaUniverseUuid: primary,
+	}
+	tflog.Info(ctx, "Switching over DR config", map[string]interface{}{
+		"dr_config_uuid":       d.Id(),
+		"new_primary_universe": replica,
+		"new_replica_universe": primary,
+	})
+	return utils.DispatchAndWait(ctx, "Switchover DR Config", cUUID, c, timeout, abortOnCancel,
+		utils.ResourceEntity, "DR Config", "Update - Switchover",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.DisasterRecoveryAPI.SwitchoverDisasterRecoveryConfig(
+				ctx, cUUID, d.Id()).DisasterRecoverySwitchoverFormData(req).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			return r.GetTaskUUID(), resp, nil
+		},
+	)
+}
+
+// failoverDRConfig promotes the DR replica to primary at the latest safe time
+// of each database.
+func failoverDRConfig(
+	ctx context.Context,
+	d *schema.ResourceData,
+	c *client.APIClient,
+	cUUID string,
+	timeout time.Duration,
+	abortOnCancel bool,
+) diag.Diagnostics {
+	primary, replica, diags := liveRoles(ctx, d, c, cUUID)
+	if diags != nil {
+		return diags
+	}
+	safetimes, response, err := c.DisasterRecoveryAPI.GetDisasterRecoverySafetime(
+		ctx, cUUID, d.Id()).Execute()
+	if err != nil {
+		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"DR Config", "Update - Fetch safe times")
+		return diag.FromErr(errMessage)
+	}
+	safetimeMap := map[string]int64{}
+	for _, s := range safetimes.GetSafetimes() {
+		safetimeMap[s.GetNamespaceId()] = s.GetSafetimeEpochUs()
+	}
+	req := client.DrConfigFailoverForm{
+		PrimaryUniverseUuid:           replica,
+		DrReplicaUniverseUuid:         primary,
+		NamespaceIdSafetimeEpochUsMap: safetimeMap,
+	}
+	tflog.Warn(ctx, "Failing over DR config", map[string]interface{}{
+		"dr_config_uuid":       d.Id(),
+		"new_primary_universe": replica,
+		"safetimes":            safetimeMap,
+	})
+	return utils.DispatchAndWait(ctx, "Failover DR Config", cUUID, c, timeout, abortOnCancel,
+		utils.ResourceEntity, "DR Config", "Update - Failover",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.DisasterRecoveryAPI.FailoverDisasterRecoveryConfig(
+				ctx, cUUID, d.Id()).DisasterRecoveryFailoverFormData(req).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			return r.GetTaskUUID(), resp, nil
+		},
+	)
+}
+
+func resourceDRConfigDelete(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+
+	if diags := utils.DispatchAndWait(ctx, "Delete DR Config", cUUID, c,
+		d.Timeout(schema.TimeoutDelete), abortOnCancel,
+		utils.ResourceEntity, "DR Config", "Delete",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.DisasterRecoveryAPI.DeleteDisasterRecoveryConfig(
+				ctx, cUUID, d.Id()).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			return r.GetTaskUUID(), resp, nil
+		},
+	); diags != nil {
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,457 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package xcluster provides the yba_xcluster_config and yba_dr_config
+// resources, which manage asynchronous replication between two universes.
+package xcluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/api"
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+// xCluster config types and replication statuses used by the YBA API.
+const (
+	basicConfigType = "Basic"
+	txnConfigType   = "Txn"
+
+	statusRunning = "Running"
+	statusPaused  = "Paused"
+)
+
+// ResourceXClusterConfig manages an xCluster replication config
+func ResourceXClusterConfig() *schema.Resource {
+	return &schema.Resource{
+		Description: "xCluster asynchronous replication from a source universe to a target " +
+			"universe. Tables can be added and removed, and replication paused and " +
+			"resumed, in place.",
+
+		CreateContext: resourceXClusterConfigCreate,
+		ReadContext:   resourceXClusterConfigRead,
+		UpdateContext: resourceXClusterConfigUpdate,
+		DeleteContext: resourceXClusterConfigDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the xCluster config.",
+			},
+			"source_universe_uuid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "UUID of the universe to replicate from.",
+			},
+			"target_universe_uuid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "UUID of the universe to replicate to.",
+			},
+			"ysql_databases": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ExactlyOneOf: []string{"ysql_databases", "ycql_tables"},
+				Description: "YSQL databases to replicate: every table of each database. " +
+					"A database with a table that is not replicated, because it was " +
+					"created later or removed from replication outside Terraform, shows " +
+					"up in the plan, and the apply adds the missing tables.",
+			},
+			"ycql_tables": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "YCQL tables to replicate, as keyspace.table names. Cannot be " +
+					"used with transactional replication.",
+			},
+			"transactional": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ForceNew:      true,
+				ConflictsWith: []string{"ycql_tables"},
+				Description: "Transactional replication, which keeps reads on the target " +
+					"universe consistent with transactions on the source. YSQL only. " +
+					"False by default.",
+			},
+			"bootstrap": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Description: "Copy the existing data of the replicated tables to the " +
+					"target universe with a backup and restore before replication " +
+					"starts. Applies to the tables of the initial setup and to tables " +
+					"added later. Without it, the tables must be empty or already in sync.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"storage_config_uuid": {
+							Type:     schema.TypeString,
+							Required: true,
+							Description: "UUID of the storage config the bootstrap backup " +
+								"is written to.",
+						},
+						"parallelism": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  8,
+							Description: "Number of concurrent commands run on nodes " +
+								"during the bootstrap backup. Default: 8.",
+						},
+					},
+				},
+			},
+			"paused": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Pause replication. False by default.",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Status of the xCluster config (e.g., Running, Updating, " +
+					"Failed, DeletedUniverse).",
+			},
+			"table_ids": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the replicated tables.",
+			},
+		},
+	}
+}
+
+// bootstrapParams builds the bootstrap request for tables, nil when the
+// bootstrap block is absent.
+func bootstrapParams(d *schema.ResourceData, tables []string) *client.BootstrapParams {
+	blocks := d.Get("bootstrap").([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil || len(tables) == 0 {
+		return nil
+	}
+	block := blocks[0].(map[string]interface{})
+	return &client.BootstrapParams{
+		Tables: tables,
+		BackupRequestParams: client.BootstrapBackupParams{
+			StorageConfigUUID: block["storage_config_uuid"].(string),
+			Parallelism:       utils.GetInt32Pointer(int32(block["parallelism"].(int))),
+		},
+	}
+}
+
+// desiredTableIDs resolves ysql_databases or ycql_tables on the source
+// universe.
+func desiredTableIDs(
+	ctx context.Context,
+	d *schema.ResourceData,
+	c *client.APIClient,
+	cUUID string,
+) ([]string, error) {
+	tables, err := listSourceTables(ctx, c, cUUID, d.Get("source_universe_uuid").(string))
+	if err != nil {
+		return nil, err
+	}
+	return resolveTableIDs(tables,
+		*utils.StringSlice(d.Get("ysql_databases").(*schema.Set).List()),
+		*utils.StringSlice(d.Get("ycql_tables").(*schema.Set).List()))
+}
+
+func resourceXClusterConfigCreate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+
+	tableIDs, err := desiredTableIDs(ctx, d, c, cUUID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	configType := basicConfigType
+	if d.Get("transactional").(bool) {
+		configType = txnConfigType
+	}
+	req := client.XClusterConfigCreateFormData{
+		Name:               d.Get("name").(string),
+		SourceUniverseUUID: d.Get("source_universe_uuid").(string),
+		TargetUniverseUUID: d.Get("target_universe_uuid").(string),
+		ConfigType:         utils.GetStringPointer(configType),
+		Tables:             tableIDs,
+		BootstrapParams:    bootstrapParams(d, tableIDs),
+	}
+
+	tflog.Info(ctx, "Creating xCluster config", map[string]interface{}{
+		"name":                 req.Name,
+		"source_universe_uuid": req.SourceUniverseUUID,
+		"target_universe_uuid": req.TargetUniverseUUID,
+		"tables":               len(tableIDs),
+	})
+	if diags := utils.DispatchAndWait(ctx, "Create xCluster Config", cUUID, c,
+		d.Timeout(schema.TimeoutCreate), abortOnCancel,
+		utils.ResourceEntity, "xCluster Config", "Create",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.AsynchronousReplicationAPI.CreateXClusterConfig(ctx, cUUID).
+				XclusterReplicationCreateFormData(req).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			d.SetId(r.GetResourceUUID())
+			return r.GetTaskUUID(), resp, nil
+		},
+	); diags != nil {
+		return diags
+	}
+
+	if d.Get("paused").(bool) {
+		if diags := editXClusterConfig(ctx, d, c, cUUID, d.Timeout(schema.TimeoutCreate),
+			abortOnCancel, "Pause", client.XClusterConfigEditFormData{
+				Status: utils.GetStringPointer(statusPaused),
+			}); diags != nil {
+			return diags
+		}
+	}
+	return resourceXClusterConfigRead(ctx, d, meta)
+}
+
+func resourceXClusterConfigRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+
+	r, response, err := c.AsynchronousReplicationAPI.GetXClusterConfig(ctx, cUUID, d.Id()).
+		Execute()
+	if err != nil {
+		if utils.IsHTTPNotFound(response) || utils.IsHTTPBadRequestNotFound(response) {
+			tflog.Warn(ctx, fmt.Sprintf("xCluster config %s not found, removing from state: %v",
+				d.Id(), err))
+			d.SetId("")
+			return nil
+		}
+		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"xCluster Config", "Read")
+		return diag.FromErr(errMessage)
+	}
+
+	if err = d.Set("name", r.GetName()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("source_universe_uuid", r.GetSourceUniverseUUID()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("target_universe_uuid", r.GetTargetUniverseUUID()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("transactional", r.GetType() == txnConfigType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("paused", r.GetPaused()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("status", r.GetStatus()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("table_ids", r.GetTables()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Map the replicated tables back to databases and tables, so tables
+	// removed outside Terraform show up in the plan. The source universe
+	// cannot list its tables while it is paused; the configured ones are kept.
+	tables, err := listSourceTables(ctx, c, cUUID, r.GetSourceUniverseUUID())
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Skipping table refresh of xCluster config %s: %v",
+			d.Id(), err))
+		return nil
+	}
+	ysqlDatabases, ycqlTables := tableSelection(tables, r.GetTables())
+	if err = d.Set("ysql_databases", ysqlDatabases); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("ycql_tables", ycqlTables); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceXClusterConfigUpdate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) (diags diag.Diagnostics) {
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	defer func() {
+		diags = append(resourceXClusterConfigRead(ctx, d, meta), diags...)
+	}()
+
+	// YBA takes one kind of edit per request, so each change is its own task.
+	// Replication is resumed before other edits and paused after them.
+	if d.HasChange("paused") && !d.Get("paused").(bool) {
+		if diags = editXClusterConfig(ctx, d, c, cUUID, timeout, abortOnCancel, "Resume",
+			client.XClusterConfigEditFormData{
+				Status: utils.GetStringPointer(statusRunning),
+			}); diags != nil {
+			return diags
+		}
+	}
+
+	if d.HasChange("name") {
+		if diags = editXClusterConfig(ctx, d, c, cUUID, timeout, abortOnCancel, "Rename",
+			client.XClusterConfigEditFormData{
+				Name: utils.GetStringPointer(d.Get("name").(string)),
+			}); diags != nil {
+			return diags
+		}
+	}
+
+	if d.HasChanges("ysql_databases", "ycql_tables") {
+		if diags = updateTables(ctx, d, c, cUUID, timeout, abortOnCancel); diags != nil {
+			return diags
+		}
+	}
+
+	if d.HasChange("paused") && d.Get("paused").(bool) {
+		if diags = editXClusterConfig(ctx, d, c, cUUID, timeout, abortOnCancel, "Pause",
+			client.XClusterConfigEditFormData{
+				Status: utils.GetStringPointer(statusPaused),
+			}); diags != nil {
+			return diags
+		}
+	}
+	return nil
+}
+
+// updateTables removes, then adds, replicated tables so the config covers
+// the configured databases or tables. YBA rejects a request that does both.
+func updateTables(
+	ctx context.Context,
+	d *schema.ResourceData,
+	c *client.APIClient,
+	cUUID string,
+	timeout time.Duration,
+	abortOnCancel bool,
+) diag.Diagnostics {
+	want, err := desiredTableIDs(ctx, d, c, cUUID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	r, response, err := c.AsynchronousReplicationAPI.GetXClusterConfig(ctx, cUUID, d.Id()).
+		Execute()
+	if err != nil {
+		errMessage := utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"xCluster Config", "Update - Fetch replicated tables")
+		return diag.FromErr(errMessage)
+	}
+	live := r.GetTables()
+	removed, added := tableDiff(live, want)
+
+	if len(removed) > 0 {
+		remaining := slices.DeleteFunc(slices.Clone(live), func(id string) bool {
+			return slices.Contains(removed, id)
+		})
+		if diags := editXClusterConfig(ctx, d, c, cUUID, timeout, abortOnCancel, "Remove Tables",
+			client.XClusterConfigEditFormData{Tables: remaining}); diags != nil {
+			return diags
+		}
+	}
+	if len(added) > 0 {
+		if diags := editXClusterConfig(ctx, d, c, cUUID, timeout, abortOnCancel, "Add Tables",
+			client.XClusterConfigEditFormData{
+				Tables:          want,
+				BootstrapParams: bootstrapParams(d, added),
+			}); diags != nil {
+			return diags
+		}
+	}
+	return nil
+}
+
+func editXClusterConfig(
+	ctx context.Context,
+	d *schema.ResourceData,
+	c *client.APIClient,
+	cUUID string,
+	timeout time.Duration,
+	abortOnCancel bool,
+	action string,
+	req client.XClusterConfigEditFormData,
+) diag.Diagnostics {
+	return utils.DispatchAndWait(ctx, action+" xCluster Config", cUUID, c, timeout, abortOnCancel,
+		utils.ResourceEntity, "xCluster Config", "Update - "+action,
+		func() (string, *http.Response, error) {
+			r, resp, err := c.AsynchronousReplicationAPI.EditXClusterConfig(ctx, cUUID, d.Id()).
+				XclusterReplicationEditFormData(req).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			return r.GetTaskUUID(), resp, nil
+		},
+	)
+}
+
+func resourceXClusterConfigDelete(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	c := meta.(*api.APIClient).YugawareClient
+	cUUID := meta.(*api.APIClient).CustomerID
+	abortOnCancel := meta.(*api.APIClient).AbortTasksOnCancel
+
+	if diags := utils.DispatchAndWait(ctx, "Delete xCluster Config", cUUID, c,
+		d.Timeout(schema.TimeoutDelete), abortOnCancel,
+		utils.ResourceEntity, "xCluster Config", "Delete",
+		func() (string, *http.Response, error) {
+			r, resp, err := c.AsynchronousReplicationAPI.DeleteXClusterConfig(
+				ctx, cUUID, d.Id()).Execute()
+			if err != nil {
+				return "", resp, err
+			}
+			return r.GetTaskUUID(), resp, nil
+		},
+	); diags != nil {
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
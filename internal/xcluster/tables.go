@@ -0,0 +1,162 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package xcluster
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	client "github.com/yugabyte/platform-go-client"
+
+	"github.com/yugabyte/terraform-provider-yba/internal/utils"
+)
+
+// Table types and relation types reported by the universe table list.
+const (
+	ysqlTableType = "PGSQL_TABLE_TYPE"
+	ycqlTableType = "YQL_TABLE_TYPE"
+
+	userTableRelation            = "USER_TABLE_RELATION"
+	colocatedParentTableRelation = "COLOCATED_PARENT_TABLE_RELATION"
+)
+
+// sourceTable is a table of the source universe that xCluster can replicate.
+type sourceTable struct {
+	id        string
+	tableType string
+	keyspace  string
+	name      string
+}
+
+// qualifiedName is the keyspace.table form ycql_tables uses.
+func (t sourceTable) qualifiedName() string {
+	return t.keyspace + "." + t.name
+}
+
+// listSourceTables lists the replicable tables of a universe. Index tables
+// are left out: YBA replicates the indexes of a replicated table with it.
+func listSourceTables(
+	ctx context.Context,
+	c *client.APIClient,
+	cUUID, universeUUID string,
+) ([]sourceTable, error) {
+	r, response, err := c.TableManagementAPI.GetAllTables(ctx, cUUID, universeUUID).Execute()
+	if err != nil {
+		return nil, utils.ErrorFromHTTPResponse(response, err, utils.ResourceEntity,
+			"xCluster Config", "List source universe tables")
+	}
+	tables := make([]sourceTable, 0, len(r))
+	for _, t := range r {
+		switch t.GetRelationType() {
+		case userTableRelation, colocatedParentTableRelation:
+		default:
+			continue
+		}
+		tables = append(tables, sourceTable{
+			id:        t.GetTableID(),
+			tableType: t.GetTableType(),
+			keyspace:  t.GetKeySpace(),
+			name:      t.GetTableName(),
+		})
+	}
+	return tables, nil
+}
+
+// resolveTableIDs returns the sorted IDs of the tables in the given YSQL
+// databases or YCQL keyspace.table names. Every database and table must exist
+// on the source universe.
+func resolveTableIDs(tables []sourceTable, ysqlDatabases, ycqlTables []string) (
+	[]string, error) {
+	matched := map[string]bool{}
+	ids := []string{}
+	for _, t := range tables {
+		var key string
+		switch {
+		case t.tableType == ysqlTableType && slices.Contains(ysqlDatabases, t.keyspace):
+			key = t.keyspace
+		case t.tableType == ycqlTableType && slices.Contains(ycqlTables, t.qualifiedName()):
+			key = t.qualifiedName()
+		default:
+			continue
+		}
+		matched[key] = true
+		ids = append(ids, t.id)
+	}
+	var missing []string
+	for _, db := range ysqlDatabases {
+		if !matched[db] {
+			missing = append(missing, "YSQL database "+db)
+		}
+	}
+	for _, table := range ycqlTables {
+		if !matched[table] {
+			missing = append(missing, "YCQL table "+table)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("no replicable tables found on the source universe for %s",
+			strings.Join(missing, ", "))
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// tableSelection maps replicated table IDs back to the YSQL databases and
+// YCQL keyspace.table names they belong to. A YSQL database is reported only
+// when all of its tables are replicated, so a table removed from replication
+// outside Terraform leaves its database out. IDs the source universe no
+// longer lists are ignored.
+func tableSelection(tables []sourceTable, ids []string) (ysqlDatabases, ycqlTables []string) {
+	replicated := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		replicated[id] = true
+	}
+	dbs := map[string]bool{}
+	cql := map[string]bool{}
+	for _, t := range tables {
+		switch t.tableType {
+		case ysqlTableType:
+			complete, seen := dbs[t.keyspace]
+			dbs[t.keyspace] = replicated[t.id] && (complete || !seen)
+		case ycqlTableType:
+			if replicated[t.id] {
+				cql[t.qualifiedName()] = true
+			}
+		}
+	}
+	maps.DeleteFunc(dbs, func(_ string, complete bool) bool { return !complete })
+	return slices.Sorted(maps.Keys(dbs)), slices.Sorted(maps.Keys(cql))
+}
+
+// tableDiff splits the change from live to want into the IDs to remove and to
+// add.
+func tableDiff(live, want []string) (removed, added []string) {
+	for _, id := range live {
+		if !slices.Contains(want, id) {
+			removed = append(removed, id)
+		}
+	}
+	for _, id := range want {
+		if !slices.Contains(live, id) {
+			added = append(added, id)
+		}
+	}
+	return removed, added
+}
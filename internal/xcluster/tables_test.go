@@ -0,0 +1,75 @@
+// Licensed to YugabyteDB, Inc. under one or more contributor license
+// agreements. See the NOTICE file distributed with this work for
+// additional information regarding copyright ownership. Yugabyte
+// licenses this file to you under the Mozilla License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// http://mozilla.org/MPL/2.0/.
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package xcluster
+
+import (
+	"reflect"
+	"testing"
+)
+
+var testSourceTables = []sourceTable{
+	{id: "t1", tableType: ysqlTableType, keyspace: "app", name: "orders"},
+	{id: "t2", tableType: ysqlTableType, keyspace: "app", name: "users"},
+	{id: "t3", tableType: ysqlTableType, keyspace: "billing", name: "invoices"},
+	{id: "t4", tableType: ycqlTableType, keyspace: "events", name: "clicks"},
+	{id: "t5", tableType: ycqlTableType, keyspace: "events", name: "views"},
+}
+
+func TestResolveTableIDs(t *testing.T) {
+	ids, err := resolveTableIDs(testSourceTables, []string{"app"}, nil)
+	if err != nil {
+		t.Fatalf("resolveTableIDs(app): %v", err)
+	}
+	if want := []string{"t1", "t2"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("resolveTableIDs(app) = %v, want %v", ids, want)
+	}
+
+	ids, err = resolveTableIDs(testSourceTables, nil, []string{"events.views"})
+	if err != nil {
+		t.Fatalf("resolveTableIDs(events.views): %v", err)
+	}
+	if want := []string{"t5"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("resolveTableIDs(events.views) = %v, want %v", ids, want)
+	}
+
+	// A YCQL keyspace name is not a YSQL database.
+	if _, err = resolveTableIDs(testSourceTables, []string{"events"}, nil); err == nil {
+		t.Error("resolveTableIDs(events) succeeded, want an error for a missing database")
+	}
+}
+
+func TestTableSelection(t *testing.T) {
+	dbs, tables := tableSelection(testSourceTables, []string{"t3", "t2", "t1", "t4", "gone"})
+	if want := []string{"app", "billing"}; !reflect.DeepEqual(dbs, want) {
+		t.Errorf("tableSelection() databases = %v, want %v", dbs, want)
+	}
+	if want := []string{"events.clicks"}; !reflect.DeepEqual(tables, want) {
+		t.Errorf("tableSelection() tables = %v, want %v", tables, want)
+	}
+
+	// app.users was removed from replication: app is no longer reported.
+	dbs, _ = tableSelection(testSourceTables, []string{"t1", "t3"})
+	if want := []string{"billing"}; !reflect.DeepEqual(dbs, want) {
+		t.Errorf("tableSelection() partial databases = %v, want %v", dbs, want)
+	}
+}
+
+func TestTableDiff(t *testing.T) {
+	removed, added := tableDiff([]string{"t1", "t2"}, []string{"t2", "t3"})
+	if !reflect.DeepEqual(removed, []string{"t1"}) || !reflect.DeepEqual(added, []string{"t3"}) {
+		t.Errorf("tableDiff() = %v, %v, want [t1], [t3]", removed, added)
+	}
+}